@@ -0,0 +1,409 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+	"git.sr.ht/~wombelix/params2env/internal/config"
+	"git.sr.ht/~wombelix/params2env/internal/generator"
+	"git.sr.ht/~wombelix/params2env/internal/manifest"
+	"git.sr.ht/~wombelix/params2env/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+// Command-line flags for the apply command
+var (
+	// applyFile is the path of the manifest file
+	applyFile string
+	// applyRegion overrides the primary AWS region of the manifest
+	applyRegion string
+	// applyRole overrides the AWS IAM role of the manifest
+	applyRole string
+	// applyAutoApprove applies the plan without asking for confirmation
+	applyAutoApprove bool
+)
+
+// Actions of an apply plan
+const (
+	applyActionCreate    = "create"
+	applyActionUpdate    = "update"
+	applyActionUnchanged = "unchanged"
+	applyActionOrphaned  = "orphaned"
+)
+
+// applyCmd represents the apply command
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply parameter definitions from a manifest file",
+	Long: `Apply parameter definitions from a manifest file.
+
+The manifest lists the desired parameters with path, type, description, KMS
+key, tags, tier, replica regions and a value source. The current state of
+every parameter is compared with the manifest in the primary and replica
+regions and the resulting plan is printed. Parameters below the manifest
+prefix that aren't listed are reported as orphaned, they are never deleted.
+
+The plan is applied after confirmation, or right away with --auto-approve.
+With --dry-run, only the plan is printed. Values are never printed.
+
+Generated values are only created for missing parameters, an existing value
+is kept and copied to replica regions where the parameter is missing.
+
+Examples:
+  # Review and apply a manifest interactively
+  params2env apply -f params.yaml
+
+  # Apply a manifest in CI
+  params2env apply -f params.yaml --auto-approve
+
+  # Print the plan only
+  params2env --dry-run apply -f params.yaml`,
+	PreRunE: validateApplyFlags,
+	RunE:    runApply,
+}
+
+// applyItem is a single entry of an apply plan
+type applyItem struct {
+	// param is the desired parameter from the manifest, empty for orphans
+	param manifest.Parameter
+	// name is the full path of the parameter
+	name string
+	// region is the AWS region of the entry
+	region string
+	// action is one of the applyAction constants
+	action string
+	// details describes the change, values are never included
+	details []string
+	// value is the value to write
+	value string
+	// kmsKeyID is the KMS key to use in the region
+	kmsKeyID *string
+}
+
+// validateApplyFlags checks if all required flags are set and valid
+func validateApplyFlags(cmd *cobra.Command, args []string) error {
+	if applyFile == "" {
+		return fmt.Errorf("required flag \"file\" not set")
+	}
+
+	if err := validation.ValidateRegion(applyRegion); err != nil {
+		return err
+	}
+
+	if err := validation.ValidateRoleARN(applyRole); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// runApply executes the apply command
+func runApply(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	m, err := manifest.Load(applyFile)
+	if err != nil {
+		return err
+	}
+
+	// Merge manifest and config with flags (flags take precedence)
+	mergeApplyConfig(m, cfg)
+
+	// Ensure region is set
+	if applyRegion == "" {
+		if applyRegion = os.Getenv("AWS_REGION"); applyRegion == "" {
+			return fmt.Errorf("AWS region must be specified via --region, manifest, config file, or AWS_REGION environment variable")
+		}
+	}
+
+	for i, p := range m.Parameters {
+		if p.Type == aws.ParameterTypeSecureString && p.KMS == "" {
+			m.Parameters[i].KMS = cfg.KMS
+		}
+		if err := validation.ValidateSecureStringRequirements(p.Type, m.Parameters[i].KMS); err != nil {
+			return fmt.Errorf("parameter %s: %w", p.Path, err)
+		}
+		if err := validation.ValidateReplicaRegions(applyRegion, p.Replicas); err != nil {
+			return fmt.Errorf("parameter %s: %w", p.Path, err)
+		}
+	}
+
+	plan, err := planApply(m, cfg.KMSKeys)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Plan for manifest '%s':\n", applyFile)
+	for _, item := range plan {
+		printApplyItem(item, "")
+	}
+	printApplySummary(plan)
+
+	if dryRun {
+		return nil
+	}
+
+	if !slices.ContainsFunc(plan, func(item applyItem) bool {
+		return item.action == applyActionCreate || item.action == applyActionUpdate
+	}) {
+		fmt.Println("No changes to apply")
+		return nil
+	}
+
+	if !applyAutoApprove {
+		ok, err := confirm("Apply the plan?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Apply cancelled, no parameters were changed")
+			return nil
+		}
+	}
+
+	fmt.Printf("Applying manifest '%s':\n", applyFile)
+	var errs []error
+	for _, item := range plan {
+		if item.action != applyActionCreate && item.action != applyActionUpdate {
+			continue
+		}
+		if err := applyPlanItem(item); err != nil {
+			printApplyItem(item, fmt.Sprintf("failed: %v", err))
+			errs = append(errs, fmt.Errorf("%s in region '%s': %w", item.name, item.region, err))
+			continue
+		}
+		printApplyItem(item, "")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to apply %d change(s): %w", len(errs), errors.Join(errs...))
+	}
+	return nil
+}
+
+// mergeApplyConfig fills region and role from the manifest, then from the
+// configuration file
+func mergeApplyConfig(m *manifest.Manifest, cfg *config.Config) {
+	if applyRegion == "" {
+		applyRegion = m.Region
+	}
+	if applyRole == "" {
+		applyRole = m.Role
+	}
+	if cfg == nil {
+		return
+	}
+	if applyRegion == "" {
+		applyRegion = cfg.Region
+	}
+	if applyRole == "" {
+		applyRole = cfg.Role
+	}
+}
+
+// planApply compares every parameter of the manifest with its current state
+// in the primary and replica regions. Orphans below the manifest prefix are
+// listed per region after the manifest parameters.
+func planApply(m *manifest.Manifest, kmsKeys map[string]string) ([]applyItem, error) {
+	ctx := context.Background()
+	var plan []applyItem
+	declared := make(map[string]map[string]bool)
+
+	for _, p := range m.Parameters {
+		regions := append([]string{applyRegion}, p.Replicas...)
+		current := make(map[string]*aws.Parameter, len(regions))
+		for _, region := range regions {
+			param, err := currentParameter(region, applyRole, p.Path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read parameter %s in region '%s': %w", p.Path, region, err)
+			}
+			current[region] = param
+			if declared[region] == nil {
+				declared[region] = make(map[string]bool)
+			}
+			declared[region][p.Path] = true
+		}
+
+		value, err := applyValue(m, p, regions, current)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, region := range regions {
+			item, err := planApplyInRegion(ctx, p, region, value, current[region], kmsKeys)
+			if err != nil {
+				return nil, err
+			}
+			plan = append(plan, item)
+		}
+	}
+
+	if m.Prefix != "" {
+		for _, region := range slices.Sorted(maps.Keys(declared)) {
+			client, err := aws.NewClient(ctx, region, applyRole)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create AWS client: %w", err)
+			}
+			names, err := client.ListParameterNames(ctx, m.Prefix)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list parameters below '%s' in region '%s': %w", m.Prefix, region, err)
+			}
+			for _, name := range names {
+				if !declared[region][name] {
+					plan = append(plan, applyItem{name: name, region: region, action: applyActionOrphaned})
+				}
+			}
+		}
+	}
+	return plan, nil
+}
+
+// applyValue returns the value to write for a parameter. A generated value
+// is only created if the parameter doesn't exist in any region, otherwise
+// the existing value is kept, preferring the primary region.
+func applyValue(m *manifest.Manifest, p manifest.Parameter, regions []string, current map[string]*aws.Parameter) (string, error) {
+	if p.Generate == nil {
+		return m.ReadValue(p)
+	}
+	for _, region := range regions {
+		if current[region] != nil {
+			return current[region].Value, nil
+		}
+	}
+	value, err := generator.Generate(p.Generate.Options())
+	if err != nil {
+		return "", fmt.Errorf("failed to generate value for parameter %s: %w", p.Path, err)
+	}
+	return value, nil
+}
+
+// planApplyInRegion compares the desired parameter with its current state
+// in a single region
+func planApplyInRegion(ctx context.Context, p manifest.Parameter, region, value string, current *aws.Parameter, kmsKeys map[string]string) (applyItem, error) {
+	item := applyItem{param: p, name: p.Path, region: region, value: value}
+
+	if p.Type == aws.ParameterTypeSecureString {
+		var err error
+		if region == applyRegion {
+			item.kmsKeyID = &p.KMS
+		} else if item.kmsKeyID, err = kmsKeyIDForRegion(p.KMS, region, kmsKeys); err != nil {
+			return item, fmt.Errorf("failed to process KMS key of parameter %s for region '%s': %w", p.Path, region, err)
+		}
+	}
+
+	desired := aws.Parameter{Name: p.Path, Value: value, Type: p.Type, Description: p.Description}
+	if item.kmsKeyID != nil {
+		desired.KeyID = *item.kmsKeyID
+	}
+
+	if current == nil {
+		item.action = applyActionCreate
+		item.details = planWrite(nil, desired, false).details
+		if p.Tier != "" {
+			item.details = append(item.details, "tier "+p.Tier)
+		}
+		for _, key := range slices.Sorted(maps.Keys(p.Tags)) {
+			item.details = append(item.details, "tag "+key)
+		}
+		return item, nil
+	}
+
+	item.details = parameterChanges(*current, desired)
+	// Intelligent-Tiering resolves to Standard or Advanced, it is never compared
+	if p.Tier != "" && p.Tier != aws.ParameterTierIntelligentTiering && p.Tier != current.Tier {
+		item.details = append(item.details, fmt.Sprintf("tier %s -> %s", orNone(current.Tier), p.Tier))
+	}
+
+	if len(p.Tags) > 0 {
+		client, err := aws.NewClient(ctx, region, applyRole)
+		if err != nil {
+			return item, fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		tags, err := client.ListTags(ctx, p.Path)
+		if err != nil {
+			return item, fmt.Errorf("failed to read tags of parameter %s in region '%s': %w", p.Path, region, err)
+		}
+		for _, key := range slices.Sorted(maps.Keys(p.Tags)) {
+			if current, ok := tags[key]; !ok {
+				item.details = append(item.details, "tag "+key+" added")
+			} else if current != p.Tags[key] {
+				item.details = append(item.details, "tag "+key+" changed")
+			}
+		}
+	}
+
+	item.action = applyActionUpdate
+	if len(item.details) == 0 {
+		item.action = applyActionUnchanged
+	}
+	return item, nil
+}
+
+// applyPlanItem writes a single plan entry
+func applyPlanItem(item applyItem) error {
+	ctx := context.Background()
+	client, err := aws.NewClient(ctx, item.region, applyRole)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	p := item.param
+	if item.action == applyActionCreate {
+		err = client.CreateParameter(ctx, p.Path, item.value, p.Description, p.Type, item.kmsKeyID, false, aws.WithTier(p.Tier))
+	} else {
+		err = client.ModifyParameter(ctx, p.Path, item.value, p.Description, p.Type, item.kmsKeyID, aws.WithTier(p.Tier))
+	}
+	if err != nil {
+		return err
+	}
+
+	return client.AddTags(ctx, p.Path, p.Tags)
+}
+
+// printApplyItem prints a single plan entry, with the result if set
+func printApplyItem(item applyItem, result string) {
+	details := item.details
+	if item.action == applyActionOrphaned {
+		details = []string{"not in manifest"}
+	}
+	if result != "" {
+		details = append(slices.Clone(details), result)
+	}
+
+	line := fmt.Sprintf("  %-9s %s in '%s'", item.action, item.name, item.region)
+	if len(details) > 0 {
+		line += " (" + strings.Join(details, ", ") + ")"
+	}
+	fmt.Println(line)
+}
+
+// printApplySummary prints the number of plan entries per action
+func printApplySummary(plan []applyItem) {
+	counts := make(map[string]int)
+	for _, item := range plan {
+		counts[item.action]++
+	}
+	fmt.Printf("Summary: %d create, %d update, %d unchanged, %d orphaned\n",
+		counts[applyActionCreate], counts[applyActionUpdate], counts[applyActionUnchanged], counts[applyActionOrphaned])
+}
+
+func init() {
+	applyCmd.Flags().StringVarP(&applyFile, "file", "f", "", "Manifest file with the parameter definitions (required)")
+	applyCmd.Flags().StringVar(&applyRegion, "region", "", "AWS region (optional, default: from manifest, config or environment)")
+	applyCmd.Flags().StringVar(&applyRole, "role", "", "AWS role ARN to assume (optional, default: from manifest or config)")
+	applyCmd.Flags().BoolVar(&applyAutoApprove, "auto-approve", false, "Apply the plan without asking for confirmation")
+}
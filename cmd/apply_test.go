@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+	"git.sr.ht/~wombelix/params2env/internal/manifest"
+)
+
+// setupApplyFlags resets the apply command flags for testing
+func setupApplyFlags() {
+	applyCmd.ResetFlags()
+	applyCmd.Flags().StringVarP(&applyFile, "file", "f", "", "Manifest file")
+	applyCmd.Flags().StringVar(&applyRegion, "region", "", "AWS region")
+	applyCmd.Flags().StringVar(&applyRole, "role", "", "AWS role ARN")
+	applyCmd.Flags().BoolVar(&applyAutoApprove, "auto-approve", false, "Apply without confirmation")
+	testRoot.AddCommand(applyCmd)
+}
+
+const testManifest = `
+region: us-west-2
+prefix: /app
+parameters:
+  - path: /app/url
+    value: https://example.com
+    description: Service URL
+    tags:
+      team: platform
+  - path: /app/db/password
+    kms: alias/app
+    tier: Advanced
+    replicas: [eu-west-1]
+    generate:
+      encoding: hex
+      length: 16
+  - path: /app/same
+    value: same
+`
+
+func TestPlanApply(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+
+	store := newRegionStore(map[string]map[string]aws.Parameter{
+		"us-west-2": {
+			"/app/url":         {Name: "/app/url", Value: "https://old.example.com", Type: aws.ParameterTypeString, Description: "Service URL"},
+			"/app/db/password": {Name: "/app/db/password", Value: "existing", Type: aws.ParameterTypeSecureString, KeyID: "alias/app", Tier: aws.ParameterTierAdvanced},
+			"/app/same":        {Name: "/app/same", Value: "same", Type: aws.ParameterTypeString},
+			"/app/legacy":      {Name: "/app/legacy", Value: "x", Type: aws.ParameterTypeString},
+		},
+		"eu-west-1": {},
+	})
+	store.install()
+
+	file := filepath.Join(t.TempDir(), "params.yaml")
+	if err := os.WriteFile(file, []byte(testManifest), 0600); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	setupApplyFlags()
+	setupDryRunFlag()
+	defer func() { applyRegion, applyRole = "", "" }()
+
+	testRoot.SetArgs([]string{"--dry-run", "apply", "-f", file})
+	if err := testRoot.Execute(); err != nil {
+		t.Fatalf("runApply() error = %v", err)
+	}
+	dryRun = false
+	if len(store.puts["us-west-2"])+len(store.puts["eu-west-1"]) != 0 {
+		t.Errorf("dry run wrote parameters: %v", store.puts)
+	}
+
+	applyRegion = "us-west-2"
+	m, err := manifest.Load(file)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	plan, err := planApply(m, nil)
+	if err != nil {
+		t.Fatalf("planApply() error = %v", err)
+	}
+
+	want := []struct{ name, region, action, details string }{
+		{"/app/url", "us-west-2", applyActionUpdate, "value changed, tag team added"},
+		{"/app/db/password", "us-west-2", applyActionUnchanged, ""},
+		{"/app/db/password", "eu-west-1", applyActionCreate, "type SecureString, kms alias/app, value set, tier Advanced"},
+		{"/app/same", "us-west-2", applyActionUnchanged, ""},
+		{"/app/legacy", "us-west-2", applyActionOrphaned, ""},
+	}
+	if len(plan) != len(want) {
+		t.Fatalf("planApply() returned %d items, want %d: %+v", len(plan), len(want), plan)
+	}
+	for i, w := range want {
+		got := plan[i]
+		if got.name != w.name || got.region != w.region || got.action != w.action || strings.Join(got.details, ", ") != w.details {
+			t.Errorf("plan[%d] = %s %s %s (%s), want %s %s %s (%s)", i,
+				got.name, got.region, got.action, strings.Join(got.details, ", "), w.name, w.region, w.action, w.details)
+		}
+	}
+	// The existing generated value is copied to the replica region
+	if plan[2].value != "existing" {
+		t.Errorf("generated value for replica = %q, want the existing value", plan[2].value)
+	}
+}
+
+func TestRunApply(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+	defer func() { stdin = os.Stdin }()
+
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		wantPuts int
+	}{
+		{name: "auto_approve", args: []string{"--auto-approve"}, wantPuts: 4},
+		{name: "confirmed", input: "yes\n", wantPuts: 4},
+		{name: "declined", input: "no\n", wantPuts: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := map[string]map[string]aws.Parameter{"us-west-2": {}, "eu-west-1": {}}
+			store := newRegionStore(params)
+			store.install()
+
+			file := filepath.Join(t.TempDir(), "params.yaml")
+			if err := os.WriteFile(file, []byte(testManifest), 0600); err != nil {
+				t.Fatalf("Failed to write manifest: %v", err)
+			}
+			stdin = strings.NewReader(tt.input)
+
+			setupApplyFlags()
+			setupDryRunFlag()
+			testRoot.SetArgs(append([]string{"apply", "-f", file}, tt.args...))
+			if err := testRoot.Execute(); err != nil {
+				t.Fatalf("runApply() error = %v", err)
+			}
+
+			if got := len(store.puts["us-west-2"]) + len(store.puts["eu-west-1"]); got != tt.wantPuts {
+				t.Fatalf("runApply() wrote %d parameter(s), want %d", got, tt.wantPuts)
+			}
+			if tt.wantPuts == 0 {
+				return
+			}
+
+			primary, replica := params["us-west-2"]["/app/db/password"], params["eu-west-1"]["/app/db/password"]
+			if len(primary.Value) != 32 || primary.Value != replica.Value {
+				t.Errorf("generated values = %q and %q, want the same 32 character value", primary.Value, replica.Value)
+			}
+			if primary.Type != aws.ParameterTypeSecureString || primary.Tier != aws.ParameterTierAdvanced {
+				t.Errorf("generated parameter = %s/%s, want SecureString/Advanced", primary.Type, primary.Tier)
+			}
+			if got := store.tags["us-west-2"]["/app/url"]["team"]; got != "platform" {
+				t.Errorf("tag team = %q, want %q", got, "platform")
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// forwardedSignals are passed on from the run command to its child
+var forwardedSignals = []os.Signal{syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP, syscall.SIGQUIT, syscall.SIGUSR1, syscall.SIGUSR2}
+
+// The reaper waits for all child processes. With PID 1, e.g. as container
+// entrypoint, orphaned processes are re-parented to the run command and
+// have to be reaped as well, otherwise they stay zombies.
+var (
+	reaperOnce    sync.Once
+	reaperMu      sync.Mutex
+	reaperWaiting = map[int]chan int{}
+)
+
+// startChild starts a child process and returns a channel that receives
+// its exit code
+func startChild(c *exec.Cmd) (<-chan int, error) {
+	reaperOnce.Do(func() {
+		sigchld := make(chan os.Signal, 1)
+		signal.Notify(sigchld, syscall.SIGCHLD)
+		go func() {
+			for range sigchld {
+				reap()
+			}
+		}()
+	})
+
+	// The lock keeps the reaper away until the child is registered
+	reaperMu.Lock()
+	defer reaperMu.Unlock()
+	if err := c.Start(); err != nil {
+		return nil, err
+	}
+	exited := make(chan int, 1)
+	reaperWaiting[c.Process.Pid] = exited
+	return exited, nil
+}
+
+// reap collects the exit status of terminated children. Only PID 1 waits for
+// any process, otherwise child processes of other code in this process, e.g.
+// an AWS credential_process, would be reaped before their owner waits.
+func reap() {
+	reaperMu.Lock()
+	defer reaperMu.Unlock()
+
+	wait := func(pid int) int {
+		for {
+			var status syscall.WaitStatus
+			waited, err := syscall.Wait4(pid, &status, syscall.WNOHANG, nil)
+			if err == syscall.EINTR {
+				continue
+			}
+			if err != nil || waited <= 0 {
+				return 0
+			}
+			if exited, ok := reaperWaiting[waited]; ok {
+				exited <- exitCode(status)
+				delete(reaperWaiting, waited)
+			}
+			return waited
+		}
+	}
+
+	if os.Getpid() == 1 {
+		for wait(-1) > 0 {
+		}
+		return
+	}
+	for pid := range reaperWaiting {
+		wait(pid)
+	}
+}
+
+// exitCode returns the exit code of a process, 128 plus the signal number
+// if it was killed by a signal, like shells do
+func exitCode(status syscall.WaitStatus) int {
+	if status.Signaled() {
+		return 128 + int(status.Signal())
+	}
+	return status.ExitStatus()
+}
+
+// stopChild asks a child process to stop with the given signal
+func stopChild(p *os.Process, name string) error {
+	sig, err := parseSignal(name)
+	if err != nil {
+		return err
+	}
+	return p.Signal(sig)
+}
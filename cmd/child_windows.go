@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+)
+
+// forwardedSignals are passed on from the run command to its child
+var forwardedSignals = []os.Signal{os.Interrupt}
+
+// startChild starts a child process and returns a channel that receives
+// its exit code
+func startChild(c *exec.Cmd) (<-chan int, error) {
+	if err := c.Start(); err != nil {
+		return nil, err
+	}
+	exited := make(chan int, 1)
+	go func() {
+		_ = c.Wait()
+		exited <- c.ProcessState.ExitCode()
+	}()
+	return exited, nil
+}
+
+// stopChild stops a child process, Windows can't send signals, so it's
+// killed
+func stopChild(p *os.Process, name string) error {
+	return p.Kill()
+}
@@ -7,12 +7,14 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
 	"git.sr.ht/~wombelix/params2env/internal/aws"
 	"git.sr.ht/~wombelix/params2env/internal/config"
+	"git.sr.ht/~wombelix/params2env/internal/generator"
 	"git.sr.ht/~wombelix/params2env/internal/validation"
 	"github.com/spf13/cobra"
 )
@@ -21,9 +23,30 @@ import (
 var (
 	// createPath is the full path of the parameter to create
 	createPath string
+	// createValues holds the values passed via --value, multiple values
+	// are joined into a single StringList parameter value
+	createValues []string
 	// createValue is the value to assign to the parameter
 	createValue string
-	// createType is the parameter type (String or SecureString)
+	// createValueFile is a file to read the value from
+	createValueFile string
+	// createValueStdin reads the value from the standard input
+	createValueStdin bool
+	// createTrim removes surrounding whitespace from a value read from a file or stdin
+	createTrim bool
+	// createGenerate generates a random value instead of taking it from a flag
+	createGenerate bool
+	// createEncoding is the encoding of the generated value
+	createEncoding string
+	// createLength is the number of characters, bytes or words of the generated value
+	createLength int
+	// createClasses are the character classes of the generated value
+	createClasses []string
+	// createExclude lists characters the generated value must not contain
+	createExclude string
+	// createPrint prints the generated value after it was stored
+	createPrint bool
+	// createType is the parameter type (String, StringList or SecureString)
 	createType string
 	// createDesc is an optional description for the parameter
 	createDesc string
@@ -33,10 +56,16 @@ var (
 	createRegion string
 	// createRole is the AWS IAM role to assume for the operation
 	createRole string
-	// createReplica is the region where the parameter should be replicated
-	createReplica string
+	// createReplicas are the regions where the parameter should be replicated
+	createReplicas []string
+	// createReplicaKMS maps a replica region to the KMS key ID used there
+	createReplicaKMS map[string]string
 	// createOverwrite determines if an existing parameter should be overwritten
 	createOverwrite bool
+	// createAtomic restores the prior state of all regions if any region fails
+	createAtomic bool
+	// createForce writes the parameter even if it is unchanged
+	createForce bool
 )
 
 // createCmd represents the create command
@@ -48,6 +77,18 @@ var createCmd = &cobra.Command{
 The parameter will be created with the specified value and type.
 Optionally, you can provide a description and KMS key for SecureString parameters.
 
+To keep secrets out of the shell history and the process list, the value can
+be read with --value-file or --value-stdin. The bytes are used as they are,
+including trailing newlines, unless --trim is set. Without any value flag,
+the value is prompted for on a terminal without echo, SecureString values
+have to be entered twice.
+
+With --generate, a random value is created with crypto/rand instead. The type
+defaults to SecureString and the value is never shown, unless --print is set.
+The encoding is one of chars (default, uses --classes), alphanumeric, hex,
+base64 or passphrase. --length sets the number of characters, the number of
+random bytes for hex and base64 or the number of words for a passphrase.
+
 Examples:
   # Create a String parameter
   params2env create --path /myapp/config/url --value https://example.com --type String
@@ -55,8 +96,32 @@ Examples:
   # Create a SecureString parameter with KMS key
   params2env create --path /myapp/secrets/api-key --value mysecret --type SecureString --kms alias/mykey
 
+  # Create a SecureString parameter from a file
+  params2env create --path /myapp/secrets/cert --value-file cert.pem --type SecureString --kms alias/mykey
+
+  # Create a SecureString parameter from the output of another command
+  pass show myapp/api-key | params2env create --path /myapp/secrets/api-key --value-stdin --trim \
+    --type SecureString --kms alias/mykey
+
+  # Create a SecureString parameter with a random 48 character password
+  params2env create --path /myapp/secrets/db-password --generate --length 48 --exclude-chars '@:' --kms alias/mykey
+
+  # Create a random hex encoded key and print it once
+  params2env create --path /myapp/secrets/session-key --generate --encoding hex --length 32 --kms alias/mykey --print
+
+  # Create a StringList parameter from repeated values or a comma separated list
+  params2env create --path /myapp/config/hosts --type StringList --value host1 --value host2
+  params2env create --path /myapp/config/hosts --type StringList --value host1,host2
+
   # Create a parameter and replicate it to another region
-  params2env create --path /myapp/config/shared --value myvalue --replica us-west-2`,
+  params2env create --path /myapp/config/shared --value myvalue --replica us-west-2
+
+  # Create a SecureString parameter in multiple replica regions with their own KMS keys
+  params2env create --path /myapp/secrets/api-key --value mysecret --type SecureString --kms alias/mykey \
+    --replica eu-west-1,us-east-1 --replica-kms eu-west-1=alias/eu-key --replica-kms us-east-1=alias/us-key
+
+  # Create a parameter in all regions or in none of them
+  params2env create --path /myapp/config/shared --value myvalue --replica us-west-2,eu-west-1 --atomic`,
 	PreRunE: validateCreateFlags,
 	RunE:    runCreate,
 }
@@ -70,16 +135,18 @@ func validateCreateFlags(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if createValue == "" {
-		return fmt.Errorf("required flag \"value\" not set")
+	if err := validateCreateValueFlags(cmd); err != nil {
+		return err
 	}
 
 	if err := validation.ValidateRegion(createRegion); err != nil {
 		return err
 	}
 
-	if err := validation.ValidateRegion(createReplica); err != nil {
-		return fmt.Errorf("invalid replica region: %w", err)
+	for _, replica := range createReplicas {
+		if err := validation.ValidateRegion(replica); err != nil {
+			return fmt.Errorf("invalid replica region: %w", err)
+		}
 	}
 
 	if err := validation.ValidateRoleARN(createRole); err != nil {
@@ -90,9 +157,52 @@ func validateCreateFlags(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	for region, key := range createReplicaKMS {
+		if err := validation.ValidateRegion(region); err != nil {
+			return fmt.Errorf("invalid replica KMS region: %w", err)
+		}
+		if err := validation.ValidateKMSKey(key); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// validateCreateValueFlags checks the value sources and the generator
+// options. A generated value defaults to the SecureString type.
+func validateCreateValueFlags(cmd *cobra.Command) error {
+	if !createGenerate {
+		for _, name := range []string{"encoding", "length", "classes", "exclude-chars", "print"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("\"%s\" requires \"generate\"", name)
+			}
+		}
+		return validateValueSources(len(createValues) > 0, createValueFile, createValueStdin)
+	}
+
+	if len(createValues) > 0 || createValueFile != "" || createValueStdin {
+		return fmt.Errorf("\"generate\" can't be used with \"value\", \"value-file\" or \"value-stdin\"")
+	}
+	if !cmd.Flags().Changed("type") {
+		createType = aws.ParameterTypeSecureString
+	}
+	if createType == aws.ParameterTypeStringList {
+		return fmt.Errorf("\"generate\" can't be used with %s parameters", aws.ParameterTypeStringList)
+	}
+	return generator.Validate(createGeneratorOptions())
+}
+
+// createGeneratorOptions returns the generator options from the flags
+func createGeneratorOptions() generator.Options {
+	return generator.Options{
+		Encoding: createEncoding,
+		Length:   createLength,
+		Classes:  createClasses,
+		Exclude:  createExclude,
+	}
+}
+
 // runCreate executes the create command
 func runCreate(cmd *cobra.Command, args []string) error {
 	// Load configuration
@@ -109,13 +219,18 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Combine the given values according to the parameter type
+	if err := resolveCreateValue(); err != nil {
+		return err
+	}
+
 	// Ensure region is set
 	if err := ensureRegionIsSet(); err != nil {
 		return err
 	}
 
-	// Validate regions are different
-	if err := validation.ValidateRegions(createRegion, createReplica); err != nil {
+	// Validate replica regions differ from the primary region and each other
+	if err := validation.ValidateReplicaRegions(createRegion, createReplicas); err != nil {
 		return err
 	}
 
@@ -124,16 +239,36 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Create parameter in primary region
-	if err := createInPrimaryRegion(); err != nil {
+	if dryRun {
+		return runPlan(createPath, append([]string{createRegion}, createReplicas...), planCreateInRegion)
+	}
+
+	if createAtomic {
+		err = runAtomic("create", createPath, createRole, createRegion, createReplicas, createInPrimaryRegion, createInReplicaRegion)
+	} else {
+		err = createInAllRegions()
+	}
+	if err != nil {
+		return err
+	}
+
+	// A generated value is only shown on request
+	if createGenerate && createPrint {
+		fmt.Println(createValue)
+	}
+	return nil
+}
+
+// createInAllRegions creates the parameter in the primary region and then
+// in the replica regions
+func createInAllRegions() error {
+	if err := createInPrimaryRegion(); err != nil && !errors.Is(err, errUnchanged) {
 		return err
 	}
 
-	// Handle replication if specified
-	if createReplica != "" {
-		if err := createInReplicaRegion(); err != nil {
-			return err
-		}
+	if len(createReplicas) > 0 {
+		results := runInRegions(createReplicas, createInReplicaRegion)
+		return reportRegionResults("create", createPath, results)
 	}
 
 	return nil
@@ -147,8 +282,16 @@ func mergeCreateConfig(cfg *config.Config) {
 	if createRegion == "" {
 		createRegion = cfg.Region
 	}
-	if createReplica == "" {
-		createReplica = cfg.Replica
+	if len(createReplicas) == 0 {
+		createReplicas = cfg.ReplicaRegions()
+	}
+	for region, key := range cfg.KMSKeys {
+		if _, ok := createReplicaKMS[region]; !ok {
+			if createReplicaKMS == nil {
+				createReplicaKMS = make(map[string]string)
+			}
+			createReplicaKMS[region] = key
+		}
 	}
 	if createRole == "" {
 		createRole = cfg.Role
@@ -161,14 +304,58 @@ func mergeCreateConfig(cfg *config.Config) {
 // validateParameterType ensures the parameter type is valid
 func validateParameterType() error {
 	paramTypeStr := strings.TrimSpace(createType)
-	if paramTypeStr != aws.ParameterTypeString && paramTypeStr != aws.ParameterTypeSecureString {
-		return fmt.Errorf("invalid parameter type: %s (must be '%s' or '%s')",
-			paramTypeStr, aws.ParameterTypeString, aws.ParameterTypeSecureString)
+	if !aws.IsValidParameterType(paramTypeStr) {
+		return fmt.Errorf("invalid parameter type: %s (must be '%s', '%s' or '%s')",
+			paramTypeStr, aws.ParameterTypeString, aws.ParameterTypeStringList, aws.ParameterTypeSecureString)
 	}
 	createType = paramTypeStr
 	return nil
 }
 
+// resolveCreateValue builds the parameter value from the --value flags.
+// StringList parameters accept either repeated --value flags or a single
+// comma separated list, all other types expect exactly one value.
+func resolveCreateValue() error {
+	values := createValues
+	if len(values) == 0 {
+		value, err := readCreateValue()
+		if err != nil {
+			return err
+		}
+		values = []string{value}
+	}
+
+	if createType != aws.ParameterTypeStringList {
+		if len(values) > 1 {
+			return fmt.Errorf("multiple values are only supported for %s parameters", aws.ParameterTypeStringList)
+		}
+		createValue = values[0]
+		return nil
+	}
+
+	items := values
+	if len(items) == 1 {
+		items = strings.Split(items[0], ",")
+	}
+	if err := validation.ValidateStringListItems(items); err != nil {
+		return err
+	}
+	createValue = strings.Join(items, ",")
+	return nil
+}
+
+// readCreateValue generates the value, reads it from --value-file or
+// --value-stdin, or prompts for it. A SecureString value has to be confirmed.
+func readCreateValue() (string, error) {
+	if createGenerate {
+		return generator.Generate(createGeneratorOptions())
+	}
+	if createValueFile != "" || createValueStdin {
+		return readValue(createValueFile, createValueStdin, createTrim)
+	}
+	return promptValue(createPath, createType == aws.ParameterTypeSecureString)
+}
+
 // ensureRegionIsSet ensures AWS region is set from flags, config, or environment
 func ensureRegionIsSet() error {
 	if createRegion == "" {
@@ -179,7 +366,8 @@ func ensureRegionIsSet() error {
 	return nil
 }
 
-// createInPrimaryRegion creates the parameter in the primary region
+// createInPrimaryRegion creates the parameter in the primary region. It
+// returns errUnchanged if the write was skipped.
 func createInPrimaryRegion() error {
 	ctx := context.Background()
 	client, err := aws.NewClient(ctx, createRegion, createRole)
@@ -192,6 +380,21 @@ func createInPrimaryRegion() error {
 		kmsKeyID = &createKMS
 	}
 
+	if createOverwrite && !createForce {
+		desired, err := createDesired(createRegion)
+		if err != nil {
+			return err
+		}
+		unchanged, err := isUnchanged(createRegion, createRole, desired)
+		if err != nil {
+			return err
+		}
+		if unchanged {
+			fmt.Printf("Parameter '%s' is unchanged in region '%s'\n", createPath, createRegion)
+			return errUnchanged
+		}
+	}
+
 	if err := client.CreateParameter(ctx, createPath, createValue, createDesc, createType, kmsKeyID, createOverwrite); err != nil {
 		return fmt.Errorf("failed to create parameter: %w", err)
 	}
@@ -200,20 +403,30 @@ func createInPrimaryRegion() error {
 	return nil
 }
 
-// createInReplicaRegion creates the parameter in the replica region
-func createInReplicaRegion() error {
+// createInReplicaRegion creates the parameter in the given replica region
+func createInReplicaRegion(replica string) error {
 	ctx := context.Background()
-	replicaClient, err := aws.NewClient(ctx, createReplica, createRole)
+	replicaClient, err := aws.NewClient(ctx, replica, createRole)
 	if err != nil {
 		return fmt.Errorf("failed to create AWS client for replica region: %w", err)
 	}
 
-	var replicaKMSKeyID *string
-	if createKMS != "" {
-		var err error
-		replicaKMSKeyID, err = getReplicaKMSKeyID(createKMS, createReplica)
+	replicaKMSKeyID, err := kmsKeyIDForRegion(createKMS, replica, createReplicaKMS)
+	if err != nil {
+		return fmt.Errorf("failed to process KMS key for replica region: %w", err)
+	}
+
+	if createOverwrite && !createForce {
+		desired, err := createDesired(replica)
+		if err != nil {
+			return err
+		}
+		unchanged, err := isUnchanged(replica, createRole, desired)
 		if err != nil {
-			return fmt.Errorf("failed to process KMS key for replica region: %w", err)
+			return err
+		}
+		if unchanged {
+			return errUnchanged
 		}
 	}
 
@@ -221,57 +434,66 @@ func createInReplicaRegion() error {
 		return fmt.Errorf("failed to create parameter in replica region: %w", err)
 	}
 
-	fmt.Printf("Successfully created parameter '%s' in replica region '%s'\n", createPath, createReplica)
 	return nil
 }
 
-// getReplicaKMSKeyID returns the KMS key ID for the replica region with proper ARN validation.
-// For KMS key aliases or key IDs, returns the input unchanged.
-// For KMS ARNs, validates the format and constructs a new ARN for the replica region.
-func getReplicaKMSKeyID(kmsKeyID, replicaRegion string) (*string, error) {
-	// Check if it looks like an ARN (starts with "arn:")
-	if !strings.HasPrefix(kmsKeyID, "arn:") {
-		// Not an ARN, treat as alias or key ID
-		return &kmsKeyID, nil
+// planCreateInRegion returns the change create would make in the given region
+func planCreateInRegion(region string) (regionPlan, error) {
+	current, err := currentParameter(region, createRole, createPath)
+	if err != nil {
+		return regionPlan{}, err
 	}
 
-	// Parse ARN with validation
-	arnParts := strings.Split(kmsKeyID, ":")
-	if len(arnParts) != 6 {
-		return nil, fmt.Errorf("invalid KMS ARN format: %s", kmsKeyID)
+	desired, err := createDesired(region)
+	if err != nil {
+		return regionPlan{}, err
 	}
 
-	if arnParts[0] != "arn" || arnParts[1] != "aws" || arnParts[2] != "kms" {
-		return nil, fmt.Errorf("invalid KMS ARN format: %s", kmsKeyID)
-	}
+	return planWrite(current, desired, createOverwrite), nil
+}
 
-	accountID := arnParts[4]
-	if accountID == "" {
-		return nil, fmt.Errorf("missing account ID in KMS ARN: %s", kmsKeyID)
+// createDesired returns the parameter create writes to the given region
+func createDesired(region string) (aws.Parameter, error) {
+	desired := aws.Parameter{Name: createPath, Value: createValue, Type: createType, Description: createDesc}
+	if createType != aws.ParameterTypeSecureString {
+		return desired, nil
 	}
 
-	keyPart := arnParts[5]
-	if !strings.HasPrefix(keyPart, "key/") {
-		return nil, fmt.Errorf("invalid key format in KMS ARN: %s", kmsKeyID)
+	if region == createRegion {
+		desired.KeyID = createKMS
+		return desired, nil
 	}
 
-	keyID := strings.TrimPrefix(keyPart, "key/")
-	if keyID == "" {
-		return nil, fmt.Errorf("missing key ID in KMS ARN: %s", kmsKeyID)
+	keyID, err := kmsKeyIDForRegion(createKMS, region, createReplicaKMS)
+	if err != nil {
+		return desired, fmt.Errorf("failed to process KMS key for replica region: %w", err)
 	}
-
-	replicaARN := fmt.Sprintf("arn:aws:kms:%s:%s:key/%s", replicaRegion, accountID, keyID)
-	return &replicaARN, nil
+	if keyID != nil {
+		desired.KeyID = *keyID
+	}
+	return desired, nil
 }
 
 func init() {
 	createCmd.Flags().StringVar(&createPath, "path", "", "Parameter path (required)")
-	createCmd.Flags().StringVar(&createValue, "value", "", "Parameter value (required)")
-	createCmd.Flags().StringVar(&createType, "type", aws.ParameterTypeString, "Parameter type (String or SecureString)")
+	createCmd.Flags().StringArrayVar(&createValues, "value", nil, "Parameter value (required, repeatable for StringList)")
+	createCmd.Flags().StringVar(&createValueFile, "value-file", "", "Read the parameter value from a file")
+	createCmd.Flags().BoolVar(&createValueStdin, "value-stdin", false, "Read the parameter value from stdin")
+	createCmd.Flags().BoolVar(&createTrim, "trim", false, "Remove surrounding whitespace from a value read from a file or stdin")
+	createCmd.Flags().BoolVar(&createGenerate, "generate", false, "Generate a random value (default type: SecureString)")
+	createCmd.Flags().StringVar(&createEncoding, "encoding", generator.EncodingChars, "Encoding of the generated value (chars, alphanumeric, hex, base64 or passphrase)")
+	createCmd.Flags().IntVar(&createLength, "length", 0, "Characters, random bytes for hex and base64 or words of the generated value (default: 32 or 6 words)")
+	createCmd.Flags().StringSliceVar(&createClasses, "classes", nil, "Character classes of the generated value (lower, upper, digits, symbols, default: all)")
+	createCmd.Flags().StringVar(&createExclude, "exclude-chars", "", "Characters the generated value must not contain")
+	createCmd.Flags().BoolVar(&createPrint, "print", false, "Print the generated value after it was stored")
+	createCmd.Flags().StringVar(&createType, "type", aws.ParameterTypeString, "Parameter type (String, StringList or SecureString)")
 	createCmd.Flags().StringVar(&createDesc, "description", "", "Parameter description")
 	createCmd.Flags().StringVar(&createKMS, "kms", "", "KMS key ID for SecureString parameters")
 	createCmd.Flags().StringVar(&createRegion, "region", "", "AWS region (optional, default: from AWS config or environment)")
 	createCmd.Flags().StringVar(&createRole, "role", "", "AWS role ARN to assume (optional)")
-	createCmd.Flags().StringVar(&createReplica, "replica", "", "Region to replicate the parameter to")
+	createCmd.Flags().StringSliceVar(&createReplicas, "replica", nil, "Regions to replicate the parameter to (repeatable or comma separated)")
+	createCmd.Flags().StringToStringVar(&createReplicaKMS, "replica-kms", nil, "KMS key ID per replica region (region=key)")
 	createCmd.Flags().BoolVar(&createOverwrite, "overwrite", false, "Overwrite existing parameter")
+	createCmd.Flags().BoolVar(&createAtomic, "atomic", false, "Roll back all regions if any region fails")
+	createCmd.Flags().BoolVar(&createForce, "force", false, "Overwrite the parameter even if it is unchanged")
 }
@@ -5,7 +5,14 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
 	"git.sr.ht/~wombelix/params2env/internal/aws"
@@ -309,3 +316,257 @@ func TestGetReplicaKMSKeyID(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveCreateValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		paramType string
+		values    []string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "single_string",
+			paramType: aws.ParameterTypeString,
+			values:    []string{"a,b"},
+			want:      "a,b",
+		},
+		{
+			name:      "multiple_values_for_string",
+			paramType: aws.ParameterTypeString,
+			values:    []string{"a", "b"},
+			wantErr:   true,
+		},
+		{
+			name:      "string_list_repeated_values",
+			paramType: aws.ParameterTypeStringList,
+			values:    []string{"a", "b", "c"},
+			want:      "a,b,c",
+		},
+		{
+			name:      "string_list_comma_list",
+			paramType: aws.ParameterTypeStringList,
+			values:    []string{"a,b,c"},
+			want:      "a,b,c",
+		},
+		{
+			name:      "string_list_item_with_comma",
+			paramType: aws.ParameterTypeStringList,
+			values:    []string{"a", "b,c"},
+			wantErr:   true,
+		},
+		{
+			name:      "string_list_empty_item",
+			paramType: aws.ParameterTypeStringList,
+			values:    []string{"a,,c"},
+			wantErr:   true,
+		},
+	}
+
+	origType, origValues, origValue := createType, createValues, createValue
+	defer func() {
+		createType, createValues, createValue = origType, origValues, origValue
+	}()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createType = tt.paramType
+			createValues = tt.values
+			createValue = ""
+
+			err := resolveCreateValue()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("resolveCreateValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && createValue != tt.want {
+				t.Errorf("resolveCreateValue() value = %q, want %q", createValue, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunCreateMultipleReplicas(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+
+	tests := []struct {
+		name        string
+		args        []string
+		failRegions map[string]bool
+		wantKeys    map[string]string
+		wantErr     bool
+	}{
+		{
+			name: "all_replicas_succeed",
+			args: []string{"--replica", "eu-west-1,us-east-1", "--replica", "ap-southeast-1"},
+			wantKeys: map[string]string{
+				"us-west-2":      "arn:aws:kms:us-west-2:123456789012:key/12345678-1234-1234-1234-123456789012",
+				"eu-west-1":      "alias/eu-key",
+				"us-east-1":      "arn:aws:kms:us-east-1:123456789012:key/12345678-1234-1234-1234-123456789012",
+				"ap-southeast-1": "arn:aws:kms:ap-southeast-1:123456789012:key/12345678-1234-1234-1234-123456789012",
+			},
+		},
+		{
+			name:        "one_replica_fails",
+			args:        []string{"--replica", "eu-west-1,us-east-1,ap-southeast-1"},
+			failRegions: map[string]bool{"us-east-1": true},
+			wantKeys: map[string]string{
+				"us-west-2":      "arn:aws:kms:us-west-2:123456789012:key/12345678-1234-1234-1234-123456789012",
+				"eu-west-1":      "alias/eu-key",
+				"ap-southeast-1": "arn:aws:kms:ap-southeast-1:123456789012:key/12345678-1234-1234-1234-123456789012",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate_replica",
+			args:    []string{"--replica", "eu-west-1,eu-west-1"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			gotKeys := make(map[string]string)
+			aws.NewClient = func(ctx context.Context, region, role string) (*aws.Client, error) {
+				return &aws.Client{SSMClient: &aws.MockSSMClient{
+					PutParamFunc: func(ctx context.Context, input *ssm.PutParameterInput, opts ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+						if tt.failRegions[region] {
+							return nil, fmt.Errorf("write failed in %s", region)
+						}
+						mu.Lock()
+						defer mu.Unlock()
+						gotKeys[region] = *input.KeyId
+						return &ssm.PutParameterOutput{}, nil
+					},
+				}}, nil
+			}
+
+			setupCreateFlags()
+			testRoot.AddCommand(createCmd)
+
+			args := []string{"create", "--path", "/test/param", "--value", "test", "--region", "us-west-2",
+				"--type", "SecureString", "--kms", "arn:aws:kms:us-west-2:123456789012:key/12345678-1234-1234-1234-123456789012",
+				"--replica-kms", "eu-west-1=alias/eu-key"}
+			testRoot.SetArgs(append(args, tt.args...))
+			err := testRoot.Execute()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantKeys != nil && !reflect.DeepEqual(gotKeys, tt.wantKeys) {
+				t.Errorf("runCreate() KMS keys = %v, want %v", gotKeys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestCreateOverwriteSkipsUnchanged(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantPuts int
+	}{
+		{"unchanged", []string{"--value", "same"}, 0},
+		{"changed", []string{"--value", "other"}, 1},
+		{"forced", []string{"--value", "same", "--force"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newRegionStore(map[string]map[string]aws.Parameter{
+				"us-west-2": {"/app/a": {Name: "/app/a", Value: "same", Type: aws.ParameterTypeString}},
+			})
+			store.install()
+
+			setupCreateFlags()
+			testRoot.AddCommand(createCmd)
+			args := []string{"create", "--path", "/app/a", "--region", "us-west-2", "--overwrite"}
+			testRoot.SetArgs(append(args, tt.args...))
+			if err := testRoot.Execute(); err != nil {
+				t.Fatalf("runCreate() error = %v", err)
+			}
+
+			if got := len(store.puts["us-west-2"]); got != tt.wantPuts {
+				t.Errorf("runCreate() wrote %d time(s), want %d", got, tt.wantPuts)
+			}
+		})
+	}
+}
+
+func TestCreateGenerate(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+
+	tests := []struct {
+		name      string
+		args      []string
+		wantErr   bool
+		wantType  string
+		wantLen   int
+		wantPrint bool
+	}{
+		{
+			name:     "defaults_to_secure_string",
+			args:     []string{"--kms", "alias/mykey"},
+			wantType: aws.ParameterTypeSecureString,
+			wantLen:  32,
+		},
+		{
+			name:      "hex_string_printed",
+			args:      []string{"--type", "String", "--encoding", "hex", "--length", "16", "--print"},
+			wantType:  aws.ParameterTypeString,
+			wantLen:   32,
+			wantPrint: true,
+		},
+		{name: "with_value", args: []string{"--kms", "alias/mykey", "--value", "x"}, wantErr: true},
+		{name: "string_list", args: []string{"--type", "StringList"}, wantErr: true},
+		{name: "invalid_encoding", args: []string{"--kms", "alias/mykey", "--encoding", "rot13"}, wantErr: true},
+		{name: "secure_string_without_kms", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := map[string]map[string]aws.Parameter{"us-west-2": {}}
+			newRegionStore(params).install()
+
+			setupCreateFlags()
+			testRoot.AddCommand(createCmd)
+			args := []string{"create", "--path", "/app/key", "--region", "us-west-2", "--generate"}
+			testRoot.SetArgs(append(args, tt.args...))
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+			err := testRoot.Execute()
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("Failed to read captured output: %v", err)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			param := params["us-west-2"]["/app/key"]
+			if param.Type != tt.wantType {
+				t.Errorf("parameter type = %s, want %s", param.Type, tt.wantType)
+			}
+			if len(param.Value) != tt.wantLen {
+				t.Errorf("parameter value length = %d, want %d", len(param.Value), tt.wantLen)
+			}
+			if printed := strings.Contains(buf.String(), param.Value); printed != tt.wantPrint {
+				t.Errorf("value printed = %v, want %v", printed, tt.wantPrint)
+			}
+		})
+	}
+}
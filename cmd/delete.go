@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync"
 
 	"git.sr.ht/~wombelix/params2env/internal/aws"
 	"git.sr.ht/~wombelix/params2env/internal/config"
@@ -24,8 +25,20 @@ var (
 	deleteRegion string
 	// deleteRole is the AWS IAM role to assume for the operation
 	deleteRole string
-	// deleteReplica is the region where the parameter replica should be deleted
-	deleteReplica string
+	// deleteReplicas are the regions where the parameter replicas should be deleted
+	deleteReplicas []string
+	// deleteAtomic restores the prior state of all regions if any region fails
+	deleteAtomic bool
+	// deletePrefix is the path of a parameter subtree to delete
+	deletePrefix string
+	// deleteRecursive confirms that all parameters below deletePrefix are deleted
+	deleteRecursive bool
+	// deleteYes skips the interactive confirmation
+	deleteYes bool
+	// deleteExpectVersion is the version the parameter must have, 0 to skip the check
+	deleteExpectVersion int64
+	// deleteExpectHash is the SHA-256 hash the current value must have, empty to skip the check
+	deleteExpectHash string
 )
 
 // deleteCmd represents the delete command
@@ -34,9 +47,17 @@ var deleteCmd = &cobra.Command{
 	Short: "Delete a parameter from SSM Parameter Store",
 	Long: `Delete a parameter from SSM Parameter Store.
 
-The parameter will be deleted from the specified region and optionally from a replica region.
+The parameter will be deleted from the specified region and optionally from replica regions.
 If the parameter doesn't exist, the command will fail with an appropriate error message.
 
+With --prefix and --recursive, all parameters below the path are deleted. The
+parameters are listed per region and have to be confirmed interactively,
+unless --yes is set.
+
+With --expect-version or --expect-value-hash, the parameter is only deleted
+if its current version or the SHA-256 hash of its current value in the
+primary region matches, otherwise the command fails with a version conflict.
+
 Examples:
   # Delete a parameter from the default region
   params2env delete --path /myapp/config/url
@@ -44,33 +65,67 @@ Examples:
   # Delete a parameter from a specific region
   params2env delete --path /myapp/config/url --region us-west-2
 
-  # Delete a parameter and its replica
-  params2env delete --path /myapp/config/url --replica us-west-2`,
+  # Delete a parameter and its replicas
+  params2env delete --path /myapp/config/url --replica us-west-2,eu-west-1
+
+  # Delete a parameter in all regions or restore it everywhere on failure
+  params2env delete --path /myapp/config/url --replica us-west-2 --atomic
+
+  # Delete a parameter only if nobody else changed it since version 7
+  params2env delete --path /myapp/config/url --expect-version 7
+
+  # Delete all parameters of a service without confirmation
+  params2env delete --prefix /old-service --recursive --yes`,
 	PreRunE: validateDeleteFlags,
 	RunE:    runDelete,
 }
 
 // validateDeleteFlags checks if all required flags are set and valid
 func validateDeleteFlags(cmd *cobra.Command, args []string) error {
-	if deletePath == "" {
-		return fmt.Errorf("required flag \"path\" not set")
+	if deletePath == "" && deletePrefix == "" {
+		return fmt.Errorf("either \"path\" or \"prefix\" must be set")
 	}
-	if err := validation.ValidateParameterPath(deletePath); err != nil {
-		return err
+	if deletePath != "" && deletePrefix != "" {
+		return fmt.Errorf("\"path\" and \"prefix\" are mutually exclusive")
+	}
+	if deletePath != "" {
+		if err := validation.ValidateParameterPath(deletePath); err != nil {
+			return err
+		}
+	}
+	if deletePrefix != "" {
+		if err := validation.ValidateParameterPath(deletePrefix); err != nil {
+			return err
+		}
+		if !deleteRecursive {
+			return fmt.Errorf("\"prefix\" requires \"recursive\"")
+		}
+		if deleteAtomic {
+			return fmt.Errorf("\"atomic\" can't be used with \"prefix\"")
+		}
+		if deleteExpectVersion != 0 || deleteExpectHash != "" {
+			return fmt.Errorf("\"expect-version\" and \"expect-value-hash\" can't be used with \"prefix\"")
+		}
 	}
 
 	if err := validation.ValidateRegion(deleteRegion); err != nil {
 		return err
 	}
 
-	if err := validation.ValidateRegion(deleteReplica); err != nil {
-		return fmt.Errorf("invalid replica region: %w", err)
+	for _, replica := range deleteReplicas {
+		if err := validation.ValidateRegion(replica); err != nil {
+			return fmt.Errorf("invalid replica region: %w", err)
+		}
 	}
 
 	if err := validation.ValidateRoleARN(deleteRole); err != nil {
 		return err
 	}
 
+	if err := validateExpectations(deleteExpectVersion, deleteExpectHash); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -90,21 +145,37 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Validate regions are different
-	if err := validation.ValidateRegions(deleteRegion, deleteReplica); err != nil {
+	// Validate replica regions differ from the primary region and each other
+	if err := validation.ValidateReplicaRegions(deleteRegion, deleteReplicas); err != nil {
 		return err
 	}
 
+	if deletePrefix != "" {
+		return deleteRecursively()
+	}
+
+	// Refuse to delete a parameter that changed since it was read
+	if err := checkExpectations(deleteRegion, deleteRole, deletePath, deleteExpectVersion, deleteExpectHash); err != nil {
+		return err
+	}
+
+	if dryRun {
+		return runPlan(deletePath, append([]string{deleteRegion}, deleteReplicas...), planDeleteInRegion)
+	}
+
+	if deleteAtomic {
+		return runAtomic("delete", deletePath, deleteRole, deleteRegion, deleteReplicas, deleteInPrimaryRegion, deleteInReplicaRegion)
+	}
+
 	// Delete parameter in primary region
 	if err := deleteInPrimaryRegion(); err != nil {
 		return err
 	}
 
-	// Handle replica if specified
-	if deleteReplica != "" {
-		if err := deleteInReplicaRegion(); err != nil {
-			return err
-		}
+	// Handle replicas if specified
+	if len(deleteReplicas) > 0 {
+		results := runInRegions(deleteReplicas, deleteInReplicaRegion)
+		return reportRegionResults("delete", deletePath, results)
 	}
 
 	return nil
@@ -118,8 +189,8 @@ func mergeDeleteConfig(cfg *config.Config) {
 	if deleteRegion == "" {
 		deleteRegion = cfg.Region
 	}
-	if deleteReplica == "" {
-		deleteReplica = cfg.Replica
+	if len(deleteReplicas) == 0 {
+		deleteReplicas = cfg.ReplicaRegions()
 	}
 	if deleteRole == "" {
 		deleteRole = cfg.Role
@@ -156,32 +227,144 @@ func deleteInPrimaryRegion() error {
 	return nil
 }
 
-// deleteInReplicaRegion deletes the parameter in the replica region
-func deleteInReplicaRegion() error {
+// deleteInReplicaRegion deletes the parameter in the given replica region
+func deleteInReplicaRegion(replica string) error {
 	ctx := context.Background()
-	replicaClient, err := aws.NewClient(ctx, deleteReplica, deleteRole)
+	replicaClient, err := aws.NewClient(ctx, replica, deleteRole)
 	if err != nil {
 		return fmt.Errorf("failed to create AWS client for replica region: %w", err)
 	}
 
-	fmt.Printf("Deleting parameter '%s' in replica region '%s'...\n", deletePath, deleteReplica)
 	if err := replicaClient.DeleteParameter(ctx, deletePath); err != nil {
 		if errors.Is(err, aws.ErrNotFound) {
-			return fmt.Errorf("parameter '%s' not found in replica region '%s'", deletePath, deleteReplica)
+			return fmt.Errorf("parameter '%s' not found in replica region '%s'", deletePath, replica)
+		}
+		return fmt.Errorf("failed to delete parameter in replica region '%s': %w", replica, err)
+	}
+
+	return nil
+}
+
+// deleteRecursively deletes all parameters below deletePrefix in the primary
+// and replica regions after they were listed and confirmed
+func deleteRecursively() error {
+	ctx := context.Background()
+	regions := append([]string{deleteRegion}, deleteReplicas...)
+
+	var mu sync.Mutex
+	names := make(map[string][]string, len(regions))
+	results := runInRegions(regions, func(region string) error {
+		client, err := aws.NewClient(ctx, region, deleteRole)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		list, err := client.ListParameterNames(ctx, deletePrefix)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		names[region] = list
+		mu.Unlock()
+		return nil
+	})
+	for _, result := range results {
+		if result.err != nil {
+			return fmt.Errorf("failed to list parameters below '%s' in region '%s': %w", deletePrefix, result.region, result.err)
+		}
+	}
+
+	total := 0
+	for _, region := range regions {
+		if len(names[region]) == 0 {
+			continue
+		}
+		fmt.Printf("Parameters below '%s' in region '%s':\n", deletePrefix, region)
+		for _, name := range names[region] {
+			fmt.Printf("  %s\n", name)
+		}
+		total += len(names[region])
+	}
+	if total == 0 {
+		fmt.Printf("No parameters found below '%s'\n", deletePrefix)
+		return nil
+	}
+
+	if dryRun {
+		fmt.Printf("Dry run, %d parameter(s) would be deleted\n", total)
+		return nil
+	}
+
+	if !deleteYes {
+		ok, err := confirm(fmt.Sprintf("Delete %d parameter(s) in %d region(s)?", total, len(regions)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("delete of parameters below '%s' aborted", deletePrefix)
 		}
-		return fmt.Errorf("failed to delete parameter in replica region '%s': %w", deleteReplica, err)
 	}
 
-	fmt.Printf("Successfully deleted parameter '%s' in replica region '%s'\n", deletePath, deleteReplica)
+	failures := make(map[string]map[string]error, len(regions))
+	results = runInRegions(regions, func(region string) error {
+		if len(names[region]) == 0 {
+			return nil
+		}
+		client, err := aws.NewClient(ctx, region, deleteRole)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		failed, err := client.DeleteParameters(ctx, names[region])
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		failures[region] = failed
+		mu.Unlock()
+		return nil
+	})
+
+	var errs []error
+	for _, result := range results {
+		if result.err != nil {
+			fmt.Printf("Failed to delete parameters below '%s' in region '%s': %v\n", deletePrefix, result.region, result.err)
+			errs = append(errs, fmt.Errorf("region '%s': %w", result.region, result.err))
+			continue
+		}
+		failed := failures[result.region]
+		for _, name := range names[result.region] {
+			if err, ok := failed[name]; ok {
+				fmt.Printf("Failed to delete parameter '%s' in region '%s': %v\n", name, result.region, err)
+				errs = append(errs, fmt.Errorf("parameter '%s' in region '%s': %w", name, result.region, err))
+			}
+		}
+		fmt.Printf("Successfully deleted %d of %d parameter(s) below '%s' in region '%s'\n",
+			len(names[result.region])-len(failed), len(names[result.region]), deletePrefix, result.region)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to delete %d parameter(s) below '%s': %w", len(errs), deletePrefix, errors.Join(errs...))
+	}
 	return nil
 }
 
+// planDeleteInRegion returns the change delete would make in the given region
+func planDeleteInRegion(region string) (regionPlan, error) {
+	current, err := currentParameter(region, deleteRole, deletePath)
+	if err != nil {
+		return regionPlan{}, err
+	}
+	return planDelete(current), nil
+}
+
 func init() {
 	deleteCmd.Flags().StringVar(&deletePath, "path", "", "Parameter path (required)")
 	deleteCmd.Flags().StringVar(&deleteRegion, "region", "", "AWS region (optional, default: from AWS config or environment)")
 	deleteCmd.Flags().StringVar(&deleteRole, "role", "", "AWS role ARN to assume (optional)")
-	deleteCmd.Flags().StringVar(&deleteReplica, "replica", "", "Region to delete the replica from")
-	if err := deleteCmd.MarkFlagRequired("path"); err != nil {
-		panic(err)
-	}
+	deleteCmd.Flags().StringSliceVar(&deleteReplicas, "replica", nil, "Regions to delete the replicas from (repeatable or comma separated)")
+	deleteCmd.Flags().BoolVar(&deleteAtomic, "atomic", false, "Restore all regions if any region fails")
+	deleteCmd.Flags().StringVar(&deletePrefix, "prefix", "", "Parameter path prefix to delete (requires --recursive)")
+	deleteCmd.Flags().BoolVar(&deleteRecursive, "recursive", false, "Delete all parameters below --prefix")
+	deleteCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Don't ask for confirmation")
+	deleteCmd.Flags().Int64Var(&deleteExpectVersion, "expect-version", 0, "Only delete if the parameter has this version in the primary region")
+	deleteCmd.Flags().StringVar(&deleteExpectHash, "expect-value-hash", "", "Only delete if the current value has this SHA-256 hash in the primary region")
 }
@@ -6,6 +6,8 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"strings"
 	"testing"
 
@@ -26,16 +28,24 @@ func setupDeleteFlags(t *testing.T) {
 	deletePath = ""
 	deleteRegion = ""
 	deleteRole = ""
-	deleteReplica = ""
+	deleteReplicas = nil
+	deletePrefix = ""
+	deleteRecursive = false
+	deleteYes = false
+	deleteExpectVersion = 0
+	deleteExpectHash = ""
 
 	deleteCmd.ResetFlags()
 	deleteCmd.Flags().StringVar(&deletePath, "path", "", "Parameter path (required)")
 	deleteCmd.Flags().StringVar(&deleteRegion, "region", "", "AWS region (optional)")
 	deleteCmd.Flags().StringVar(&deleteRole, "role", "", "AWS role ARN to assume (optional)")
-	deleteCmd.Flags().StringVar(&deleteReplica, "replica", "", "Region to delete the replica from")
-	if err := deleteCmd.MarkFlagRequired("path"); err != nil {
-		t.Fatalf("Failed to mark path flag as required: %v", err)
-	}
+	deleteCmd.Flags().StringSliceVar(&deleteReplicas, "replica", nil, "Regions to delete the replicas from")
+	deleteCmd.Flags().BoolVar(&deleteAtomic, "atomic", false, "Restore all regions on failure")
+	deleteCmd.Flags().StringVar(&deletePrefix, "prefix", "", "Parameter path prefix to delete")
+	deleteCmd.Flags().BoolVar(&deleteRecursive, "recursive", false, "Delete all parameters below prefix")
+	deleteCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Don't ask for confirmation")
+	deleteCmd.Flags().Int64Var(&deleteExpectVersion, "expect-version", 0, "Expected version")
+	deleteCmd.Flags().StringVar(&deleteExpectHash, "expect-value-hash", "", "Expected value hash")
 	testRoot.AddCommand(deleteCmd)
 }
 
@@ -244,6 +254,7 @@ func TestDeleteReplicaNotFound(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts.output.Reset()
+			setupDeleteFlags(t)
 
 			// Track which region is being called to return appropriate error
 			callCount := 0
@@ -289,3 +300,86 @@ func TestDeleteReplicaNotFound(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteRecursive(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+	defer func() { stdin = os.Stdin }()
+
+	initial := func() map[string]map[string]aws.Parameter {
+		params := map[string]map[string]aws.Parameter{"us-west-2": {}, "eu-west-1": {}}
+		for i := 0; i < 12; i++ {
+			name := fmt.Sprintf("/old/p%02d", i)
+			params["us-west-2"][name] = aws.Parameter{Name: name, Value: "v", Type: aws.ParameterTypeString}
+		}
+		params["us-west-2"]["/keep/a"] = aws.Parameter{Name: "/keep/a", Value: "v", Type: aws.ParameterTypeString}
+		params["eu-west-1"]["/old/p00"] = aws.Parameter{Name: "/old/p00", Value: "v", Type: aws.ParameterTypeString}
+		return params
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		input   string
+		wantErr bool
+		// wantLeft is the number of parameters left per region
+		wantLeft map[string]int
+	}{
+		{
+			name:     "confirmed",
+			input:    "yes\n",
+			wantLeft: map[string]int{"us-west-2": 1, "eu-west-1": 0},
+		},
+		{
+			name:     "declined",
+			input:    "n\n",
+			wantErr:  true,
+			wantLeft: map[string]int{"us-west-2": 13, "eu-west-1": 1},
+		},
+		{
+			name:     "no_input",
+			wantErr:  true,
+			wantLeft: map[string]int{"us-west-2": 13, "eu-west-1": 1},
+		},
+		{
+			name:     "yes_flag",
+			args:     []string{"--yes"},
+			wantLeft: map[string]int{"us-west-2": 1, "eu-west-1": 0},
+		},
+		{
+			name:     "without_recursive",
+			args:     []string{"--recursive=false", "--yes"},
+			wantErr:  true,
+			wantLeft: map[string]int{"us-west-2": 13, "eu-west-1": 1},
+		},
+		{
+			name:     "with_path",
+			args:     []string{"--path", "/old/p00", "--yes"},
+			wantErr:  true,
+			wantLeft: map[string]int{"us-west-2": 13, "eu-west-1": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := initial()
+			store := newRegionStore(params)
+			store.install()
+			stdin = strings.NewReader(tt.input)
+
+			setupDeleteFlags(t)
+			args := []string{"delete", "--prefix", "/old", "--recursive", "--region", "us-west-2", "--replica", "eu-west-1"}
+			testRoot.SetArgs(append(args, tt.args...))
+			err := testRoot.Execute()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("deleteRecursively() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for region, want := range tt.wantLeft {
+				if got := len(params[region]); got != want {
+					t.Errorf("deleteRecursively() left %d parameter(s) in region '%s', want %d", got, region, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+)
+
+// validateExpectations checks the --expect-version and --expect-value-hash
+// flags. A version of 0 and an empty hash disable the respective check.
+func validateExpectations(version int64, valueHash string) error {
+	if version < 0 {
+		return fmt.Errorf("invalid expected version: %d (must be greater than 0)", version)
+	}
+	if valueHash != "" {
+		if b, err := hex.DecodeString(valueHash); err != nil || len(b) != 32 {
+			return fmt.Errorf("invalid expected value hash: %s (must be a hex encoded SHA-256 hash)", valueHash)
+		}
+	}
+	return nil
+}
+
+// checkExpectations reads the parameter in a region and returns an error
+// wrapping aws.ErrVersionConflict if its version or the hash of its value
+// doesn't match the expected one. Nothing is read if no expectation is set.
+func checkExpectations(region, role, path string, version int64, valueHash string) error {
+	if version == 0 && valueHash == "" {
+		return nil
+	}
+
+	ctx := context.Background()
+	client, err := aws.NewClient(ctx, region, role)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	current, err := client.GetParameterInfo(ctx, path)
+	if err != nil {
+		if errors.Is(err, aws.ErrNotFound) {
+			return fmt.Errorf("parameter '%s' not found in region '%s'", path, region)
+		}
+		return fmt.Errorf("failed to read current version: %w", err)
+	}
+
+	if version != 0 && current.Version != version {
+		return fmt.Errorf("%w: parameter '%s' is at version %d in region '%s', expected version %d",
+			aws.ErrVersionConflict, path, current.Version, region, version)
+	}
+	if valueHash != "" && hashValue(current.Value) != strings.ToLower(valueHash) {
+		return fmt.Errorf("%w: value of parameter '%s' in region '%s' doesn't match the expected hash",
+			aws.ErrVersionConflict, path, region)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+)
+
+func TestValidateExpectations(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   int64
+		valueHash string
+		wantErr   bool
+	}{
+		{name: "unset", version: 0},
+		{name: "valid_version", version: 3},
+		{name: "negative_version", version: -1, wantErr: true},
+		{name: "valid_hash", valueHash: hashValue("old")},
+		{name: "uppercase_hash", valueHash: strings.ToUpper(hashValue("old"))},
+		{name: "short_hash", valueHash: "abc123", wantErr: true},
+		{name: "no_hex", valueHash: strings.Repeat("z", 64), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateExpectations(tt.version, tt.valueHash)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateExpectations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExpectVersion(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+
+	tests := []struct {
+		name         string
+		setup        func()
+		args         []string
+		wantConflict bool
+		wantErr      bool
+		// want is the expected value afterwards, empty if the parameter must not exist
+		want string
+	}{
+		{
+			name:  "modify_version_matches",
+			setup: setupModifyFlags,
+			args:  []string{"modify", "--path", "/app/a", "--value", "new", "--expect-version", "3"},
+			want:  "new",
+		},
+		{
+			name:         "modify_version_conflict",
+			setup:        setupModifyFlags,
+			args:         []string{"modify", "--path", "/app/a", "--value", "new", "--expect-version", "2"},
+			wantConflict: true,
+			want:         "old",
+		},
+		{
+			name:  "modify_hash_matches",
+			setup: setupModifyFlags,
+			args:  []string{"modify", "--path", "/app/a", "--value", "new", "--expect-value-hash", hashValue("old")},
+			want:  "new",
+		},
+		{
+			name:         "modify_hash_conflict",
+			setup:        setupModifyFlags,
+			args:         []string{"modify", "--path", "/app/a", "--value", "new", "--expect-value-hash", hashValue("other")},
+			wantConflict: true,
+			want:         "old",
+		},
+		{
+			name:    "modify_not_found",
+			setup:   setupModifyFlags,
+			args:    []string{"modify", "--path", "/app/missing", "--value", "new", "--expect-version", "1"},
+			wantErr: true,
+			want:    "old",
+		},
+		{
+			name:  "delete_version_matches",
+			setup: func() { setupDeleteFlags(t) },
+			args:  []string{"delete", "--path", "/app/a", "--expect-version", "3"},
+		},
+		{
+			name:         "delete_version_conflict",
+			setup:        func() { setupDeleteFlags(t) },
+			args:         []string{"delete", "--path", "/app/a", "--expect-version", "4"},
+			wantConflict: true,
+			want:         "old",
+		},
+		{
+			name:         "delete_hash_conflict",
+			setup:        func() { setupDeleteFlags(t) },
+			args:         []string{"delete", "--path", "/app/a", "--expect-value-hash", hashValue("other")},
+			wantConflict: true,
+			want:         "old",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := map[string]map[string]aws.Parameter{
+				"us-west-2": {"/app/a": {Name: "/app/a", Value: "old", Type: aws.ParameterTypeString, Version: 3}},
+			}
+			newRegionStore(params).install()
+
+			tt.setup()
+			testRoot.AddCommand(modifyCmd, deleteCmd)
+			testRoot.SetArgs(append(tt.args, "--region", "us-west-2"))
+			err := testRoot.Execute()
+
+			if conflict := errors.Is(err, aws.ErrVersionConflict); conflict != tt.wantConflict {
+				t.Errorf("Execute() error = %v, want version conflict %v", err, tt.wantConflict)
+			}
+			if (err != nil) != (tt.wantErr || tt.wantConflict) {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr || tt.wantConflict)
+			}
+
+			param, ok := params["us-west-2"]["/app/a"]
+			if tt.want == "" {
+				if ok {
+					t.Errorf("parameter still exists")
+				}
+				return
+			}
+			if !ok || param.Value != tt.want {
+				t.Errorf("parameter value = %q, want %q", param.Value, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+	"git.sr.ht/~wombelix/params2env/internal/backup"
+	"git.sr.ht/~wombelix/params2env/internal/config"
+	"git.sr.ht/~wombelix/params2env/internal/fileutil"
+	"git.sr.ht/~wombelix/params2env/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+// Command-line flags for the export command
+var (
+	// exportPrefix is the path of the parameter subtree to export
+	exportPrefix string
+	// exportOut is the backup file to write
+	exportOut string
+	// exportFormat is the backup format, json or yaml
+	exportFormat string
+	// exportRegion is the AWS region to export from
+	exportRegion string
+	// exportRole is the AWS IAM role to assume
+	exportRole string
+	// exportEncrypt encrypts the backup with a passphrase
+	exportEncrypt bool
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a parameter subtree to a backup file",
+	Long: `Export a parameter subtree to a backup file.
+
+All parameters below the prefix are written to the backup with name, type,
+decrypted value, description, KMS key ID, tier, tags and version. The format
+is JSON or YAML, by default chosen by the file extension. The file is created
+with 0600 permissions.
+
+With --encrypt, the backup is encrypted with a passphrase taken from the
+PARAMS2ENV_PASSPHRASE environment variable or prompted for on the terminal.
+Use the import command to restore a backup.
+
+Examples:
+  # Export a subtree to JSON
+  params2env export --prefix /myapp --out backup.json
+
+  # Export an encrypted YAML backup
+  params2env export --prefix /myapp --out backup.yaml --encrypt`,
+	PreRunE: validateExportFlags,
+	RunE:    runExport,
+}
+
+// validateExportFlags checks if all required flags are set and valid
+func validateExportFlags(cmd *cobra.Command, args []string) error {
+	if exportPrefix == "" {
+		return fmt.Errorf("required flag \"prefix\" not set")
+	}
+	if err := validation.ValidateParameterPath(exportPrefix); err != nil {
+		return err
+	}
+
+	if exportOut == "" {
+		return fmt.Errorf("required flag \"out\" not set")
+	}
+	if exportFormat == "" {
+		exportFormat = backupFormatFor(exportOut)
+	}
+	if exportFormat != backup.FormatJSON && exportFormat != backup.FormatYAML {
+		return fmt.Errorf("invalid format: %s (must be '%s' or '%s')", exportFormat, backup.FormatJSON, backup.FormatYAML)
+	}
+
+	if err := validation.ValidateRegion(exportRegion); err != nil {
+		return err
+	}
+
+	if err := validation.ValidateRoleARN(exportRole); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// backupFormatFor returns the backup format for a file name, YAML for .yaml
+// and .yml files and JSON otherwise
+func backupFormatFor(file string) string {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		return backup.FormatYAML
+	}
+	return backup.FormatJSON
+}
+
+// runExport executes the export command
+func runExport(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Merge config with flags (flags take precedence)
+	mergeExportConfig(cfg)
+
+	// Ensure region is set
+	if exportRegion == "" {
+		if exportRegion = os.Getenv("AWS_REGION"); exportRegion == "" {
+			return fmt.Errorf("AWS region must be specified via --region, config file, or AWS_REGION environment variable")
+		}
+	}
+
+	var passphrase string
+	if exportEncrypt {
+		if passphrase, err = readPassphrase(true); err != nil {
+			return err
+		}
+	}
+
+	params, err := exportParameters()
+	if err != nil {
+		return err
+	}
+
+	data, err := backup.Marshal(backup.New(exportPrefix, exportRegion, params), exportFormat)
+	if err != nil {
+		return err
+	}
+	if exportEncrypt {
+		if data, err = backup.Encrypt(data, passphrase); err != nil {
+			return fmt.Errorf("failed to encrypt backup: %w", err)
+		}
+	}
+
+	// A new file with 0600 permissions replaces an existing one, so the
+	// values are never readable with its old permissions
+	if err := fileutil.WriteAtomic(exportOut, data, 0600); err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+
+	fmt.Printf("Successfully exported %d parameter(s) below '%s' from region '%s' to '%s'\n",
+		len(params), exportPrefix, exportRegion, exportOut)
+	return nil
+}
+
+// mergeExportConfig merges configuration from file with command line flags
+func mergeExportConfig(cfg *config.Config) {
+	if cfg == nil {
+		return
+	}
+	if exportRegion == "" {
+		exportRegion = cfg.Region
+	}
+	if exportRole == "" {
+		exportRole = cfg.Role
+	}
+}
+
+// exportParameters reads all parameters below the prefix with their tags
+func exportParameters() ([]backup.Parameter, error) {
+	ctx := context.Background()
+	client, err := aws.NewClient(ctx, exportRegion, exportRole)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	list, err := client.ListParameters(ctx, exportPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parameters below '%s' in region '%s': %w", exportPrefix, exportRegion, err)
+	}
+
+	params := make([]backup.Parameter, 0, len(list))
+	for _, p := range list {
+		tags, err := client.ListTags(ctx, p.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tags of parameter %s: %w", p.Name, err)
+		}
+		if len(tags) == 0 {
+			tags = nil
+		}
+		params = append(params, backup.Parameter{
+			Name:        p.Name,
+			Type:        p.Type,
+			Value:       p.Value,
+			Description: p.Description,
+			KeyID:       p.KeyID,
+			Tier:        p.Tier,
+			Tags:        tags,
+			Version:     p.Version,
+		})
+	}
+	return params, nil
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportPrefix, "prefix", "", "Parameter path prefix to export recursively (required)")
+	exportCmd.Flags().StringVar(&exportOut, "out", "", "Backup file to write (required)")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "", "Backup format, json or yaml (optional, default: from file extension)")
+	exportCmd.Flags().StringVar(&exportRegion, "region", "", "AWS region (optional, default: from config or environment)")
+	exportCmd.Flags().StringVar(&exportRole, "role", "", "AWS role ARN to assume (optional)")
+	exportCmd.Flags().BoolVar(&exportEncrypt, "encrypt", false, "Encrypt the backup with a passphrase")
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+	"git.sr.ht/~wombelix/params2env/internal/backup"
+)
+
+// setupExportFlags resets the export command flags for testing
+func setupExportFlags() {
+	exportCmd.ResetFlags()
+	exportCmd.Flags().StringVar(&exportPrefix, "prefix", "", "Parameter path prefix")
+	exportCmd.Flags().StringVar(&exportOut, "out", "", "Backup file")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "", "Backup format")
+	exportCmd.Flags().StringVar(&exportRegion, "region", "", "AWS region")
+	exportCmd.Flags().StringVar(&exportRole, "role", "", "AWS role ARN")
+	exportCmd.Flags().BoolVar(&exportEncrypt, "encrypt", false, "Encrypt the backup")
+	testRoot.AddCommand(exportCmd)
+}
+
+// testBackupStore returns a region store with parameters to export
+func testBackupStore() *regionStore {
+	store := newRegionStore(map[string]map[string]aws.Parameter{
+		"eu-central-1": {
+			"/app/url":      {Name: "/app/url", Value: "https://example.com", Type: aws.ParameterTypeString, Description: "Service URL", Tier: aws.ParameterTierStandard, Version: 4},
+			"/app/password": {Name: "/app/password", Value: "s3cret", Type: aws.ParameterTypeSecureString, KeyID: "alias/app", Tier: aws.ParameterTierAdvanced, Version: 2},
+			"/other/value":  {Name: "/other/value", Value: "x", Type: aws.ParameterTypeString, Version: 1},
+		},
+	})
+	store.tags["eu-central-1"] = map[string]map[string]string{"/app/url": {"team": "platform"}}
+	return store
+}
+
+func TestRunExport(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+
+	tests := []struct {
+		name     string
+		file     string
+		args     []string
+		wantYAML bool
+		existing bool
+		errMsg   string
+	}{
+		{name: "json", file: "backup.json"},
+		{name: "yaml_by_extension", file: "backup.yml", wantYAML: true},
+		{name: "yaml_by_flag", file: "backup.txt", args: []string{"--format", "yaml"}, wantYAML: true},
+		{name: "encrypted", file: "backup.json", args: []string{"--encrypt"}},
+		{name: "replaces_readable_file", file: "backup.json", existing: true},
+		{name: "invalid_format", file: "backup.json", args: []string{"--format", "xml"}, errMsg: "invalid format"},
+		{name: "missing_prefix", file: "backup.json", args: []string{"--prefix", ""}, errMsg: "required flag \"prefix\" not set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testBackupStore().install()
+			t.Setenv(passphraseEnv, "correct horse")
+			out := filepath.Join(t.TempDir(), tt.file)
+			if tt.existing {
+				if err := os.WriteFile(out, []byte("old"), 0644); err != nil {
+					t.Fatalf("Failed to write existing file: %v", err)
+				}
+			}
+
+			setupExportFlags()
+			args := append([]string{"export", "--prefix", "/app", "--out", out, "--region", "eu-central-1"}, tt.args...)
+			testRoot.SetArgs(args)
+			err := testRoot.Execute()
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("runExport() error = %v, want error containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runExport() error = %v", err)
+			}
+
+			info, err := os.Stat(out)
+			if err != nil {
+				t.Fatalf("Failed to stat backup: %v", err)
+			}
+			if info.Mode().Perm() != 0600 {
+				t.Errorf("backup permissions = %o, want 600", info.Mode().Perm())
+			}
+
+			data, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatalf("Failed to read backup: %v", err)
+			}
+			if exportEncrypt {
+				if !backup.IsEncrypted(data) {
+					t.Fatal("backup isn't encrypted")
+				}
+				if data, err = backup.Decrypt(data, "correct horse"); err != nil {
+					t.Fatalf("Decrypt() error = %v", err)
+				}
+			}
+			if isJSON := strings.HasPrefix(string(data), "{"); isJSON == tt.wantYAML {
+				t.Errorf("backup format JSON = %v, want YAML %v", isJSON, tt.wantYAML)
+			}
+
+			b, err := backup.Unmarshal(data)
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if len(b.Parameters) != 2 {
+				t.Fatalf("backup has %d parameter(s), want 2: %+v", len(b.Parameters), b.Parameters)
+			}
+			password, url := b.Parameters[0], b.Parameters[1]
+			if password.Value != "s3cret" || password.KeyID != "alias/app" || password.Tier != aws.ParameterTierAdvanced || password.Version != 2 {
+				t.Errorf("exported password = %+v", password)
+			}
+			if url.Description != "Service URL" || url.Tags["team"] != "platform" || url.Version != 4 {
+				t.Errorf("exported url = %+v", url)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// runHook runs a command through the shell of the operating system, with
+// the output passed through to stdout and stderr
+//
+// Parameters:
+//   - command: The command line, e.g. "systemctl restart myapp"
+//
+// Returns:
+//   - An error if the command can't be started or exits with an error
+func runHook(command string) error {
+	shell, flag := "/bin/sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	c := exec.Command(shell, flag, command)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
@@ -0,0 +1,324 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+	"git.sr.ht/~wombelix/params2env/internal/backup"
+	"git.sr.ht/~wombelix/params2env/internal/config"
+	"git.sr.ht/~wombelix/params2env/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+// Command-line flags for the import command
+var (
+	// importIn is the backup file to read
+	importIn string
+	// importRegion is the AWS region to import to
+	importRegion string
+	// importRole is the AWS IAM role to assume
+	importRole string
+	// importOnConflict is the policy for existing parameters that differ
+	importOnConflict string
+)
+
+// importKMSKeys maps a region to the KMS key ID used for SecureString
+// parameters, taken from the kms_keys configuration setting
+var importKMSKeys map[string]string
+
+// Conflict policies of the import command
+const (
+	conflictSkip      = "skip"
+	conflictOverwrite = "overwrite"
+	conflictFail      = "fail"
+)
+
+// Actions of an import plan
+const (
+	importActionCreate    = "create"
+	importActionUpdate    = "update"
+	importActionUnchanged = "unchanged"
+	importActionSkip      = "skip"
+	importActionConflict  = "conflict"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Restore parameters from a backup file",
+	Long: `Restore parameters from a backup file.
+
+The backup is read from a file written by the export command. Encrypted
+backups are detected automatically, the passphrase is taken from the
+PARAMS2ENV_PASSPHRASE environment variable or prompted for on the terminal.
+
+Missing parameters are created with type, value, description, tier and tags
+from the backup. SecureString parameters use the KMS key configured for the
+region in kms_keys, otherwise the key from the backup. Parameter versions are
+recorded in the backup but can't be restored, SSM assigns new versions.
+
+Existing parameters with a different value, type or description are
+conflicts, handled by --on-conflict:
+  fail       Abort before any parameter is written (default)
+  skip       Keep the existing parameter
+  overwrite  Replace the existing parameter
+
+With --dry-run, the plan is printed without writing any parameter.
+
+Examples:
+  # Restore a backup to the region it was exported from
+  params2env import --in backup.json
+
+  # Restore to another region and replace existing parameters
+  params2env import --in backup.json --region eu-west-1 --on-conflict overwrite`,
+	PreRunE: validateImportFlags,
+	RunE:    runImport,
+}
+
+// importItem is a single entry of an import plan
+type importItem struct {
+	// param is the parameter from the backup
+	param backup.Parameter
+	// action is one of the importAction constants
+	action string
+	// reasons lists the detected differences or why a parameter is skipped
+	reasons []string
+}
+
+// validateImportFlags checks if all required flags are set and valid
+func validateImportFlags(cmd *cobra.Command, args []string) error {
+	if importIn == "" {
+		return fmt.Errorf("required flag \"in\" not set")
+	}
+
+	switch importOnConflict {
+	case conflictSkip, conflictOverwrite, conflictFail:
+	default:
+		return fmt.Errorf("invalid conflict policy: %s (must be '%s', '%s' or '%s')",
+			importOnConflict, conflictSkip, conflictOverwrite, conflictFail)
+	}
+
+	if err := validation.ValidateRegion(importRegion); err != nil {
+		return err
+	}
+
+	if err := validation.ValidateRoleARN(importRole); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// runImport executes the import command
+func runImport(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	b, err := readBackup(importIn)
+	if err != nil {
+		return err
+	}
+
+	// Merge config with flags (flags take precedence)
+	mergeImportConfig(cfg)
+
+	// Ensure region is set, the region of the backup is the last resort
+	if importRegion == "" {
+		if importRegion = os.Getenv("AWS_REGION"); importRegion == "" {
+			if importRegion = b.Region; importRegion == "" {
+				return fmt.Errorf("AWS region must be specified via --region, config file, AWS_REGION environment variable, or backup")
+			}
+		}
+	}
+
+	ctx := context.Background()
+	client, err := aws.NewClient(ctx, importRegion, importRole)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	list, err := client.ListParameters(ctx, b.Prefix)
+	if err != nil {
+		return fmt.Errorf("failed to read parameters below '%s' in region '%s': %w", b.Prefix, importRegion, err)
+	}
+	existing := make(map[string]aws.Parameter, len(list))
+	for _, param := range list {
+		existing[param.Name] = param
+	}
+
+	plan := planImport(b.Parameters, existing, importOnConflict)
+
+	if dryRun || hasImportConflicts(plan) {
+		fmt.Printf("Plan to import '%s' to region '%s':\n", importIn, importRegion)
+		for _, item := range plan {
+			printImportItem(item, "")
+		}
+		printImportSummary(plan)
+		if !dryRun {
+			return fmt.Errorf("parameters already exist with different values, use --on-conflict skip or overwrite to import, no parameters were changed")
+		}
+		return nil
+	}
+
+	fmt.Printf("Importing '%s' to region '%s':\n", importIn, importRegion)
+	var errs []error
+	for _, item := range plan {
+		if err := applyImportItem(ctx, client, item); err != nil {
+			printImportItem(item, fmt.Sprintf("failed: %v", err))
+			errs = append(errs, fmt.Errorf("%s: %w", item.param.Name, err))
+			continue
+		}
+		printImportItem(item, "")
+	}
+	printImportSummary(plan)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to import %d parameter(s): %w", len(errs), errors.Join(errs...))
+	}
+	return nil
+}
+
+// readBackup reads a backup file and decrypts it if needed
+func readBackup(file string) (*backup.Backup, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup file: %w", err)
+	}
+
+	if backup.IsEncrypted(data) {
+		passphrase, err := readPassphrase(false)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = backup.Decrypt(data, passphrase); err != nil {
+			return nil, fmt.Errorf("failed to decrypt backup: %w", err)
+		}
+	}
+
+	b, err := backup.Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup %s: %w", file, err)
+	}
+	return b, nil
+}
+
+// mergeImportConfig merges configuration from file with command line flags
+func mergeImportConfig(cfg *config.Config) {
+	if cfg == nil {
+		return
+	}
+	if importRegion == "" {
+		importRegion = cfg.Region
+	}
+	if importRole == "" {
+		importRole = cfg.Role
+	}
+	importKMSKeys = cfg.KMSKeys
+}
+
+// planImport compares the backup with the existing parameters and returns
+// the action per parameter in backup order
+func planImport(params []backup.Parameter, existing map[string]aws.Parameter, onConflict string) []importItem {
+	plan := make([]importItem, 0, len(params))
+	for _, p := range params {
+		item := importItem{param: p}
+		current, ok := existing[p.Name]
+		switch {
+		case !ok:
+			item.action = importActionCreate
+		default:
+			desired := aws.Parameter{Name: p.Name, Value: p.Value, Type: p.Type, Description: p.Description}
+			item.reasons = compareParameters(desired, current)
+			switch {
+			case len(item.reasons) == 0:
+				item.action = importActionUnchanged
+			case onConflict == conflictOverwrite:
+				item.action = importActionUpdate
+			case onConflict == conflictSkip:
+				item.action = importActionSkip
+				item.reasons = append(item.reasons, "use --on-conflict overwrite to replace")
+			default:
+				item.action = importActionConflict
+			}
+		}
+		plan = append(plan, item)
+	}
+	return plan
+}
+
+// hasImportConflicts reports whether the plan contains unresolved conflicts
+func hasImportConflicts(plan []importItem) bool {
+	for _, item := range plan {
+		if item.action == importActionConflict {
+			return true
+		}
+	}
+	return false
+}
+
+// applyImportItem writes a single plan entry
+func applyImportItem(ctx context.Context, client *aws.Client, item importItem) error {
+	if item.action != importActionCreate && item.action != importActionUpdate {
+		return nil
+	}
+
+	p := item.param
+	var kmsKeyID *string
+	if p.Type == aws.ParameterTypeSecureString {
+		var err error
+		kmsKeyID, err = kmsKeyIDForRegion(p.KeyID, importRegion, importKMSKeys)
+		if err != nil {
+			return fmt.Errorf("failed to process KMS key: %w", err)
+		}
+	}
+
+	if err := client.CreateParameter(ctx, p.Name, p.Value, p.Description, p.Type, kmsKeyID,
+		item.action == importActionUpdate, aws.WithTier(p.Tier)); err != nil {
+		return err
+	}
+	return client.AddTags(ctx, p.Name, p.Tags)
+}
+
+// printImportItem prints a single plan entry, with the result if set
+func printImportItem(item importItem, result string) {
+	details := slices.Clone(item.reasons)
+	if result != "" {
+		details = append(details, result)
+	}
+
+	line := fmt.Sprintf("  %-9s %s", item.action, item.param.Name)
+	if len(details) > 0 {
+		line += " (" + strings.Join(details, ", ") + ")"
+	}
+	fmt.Println(line)
+}
+
+// printImportSummary prints the number of parameters per action
+func printImportSummary(plan []importItem) {
+	counts := make(map[string]int)
+	for _, item := range plan {
+		counts[item.action]++
+	}
+	fmt.Printf("Summary: %d create, %d update, %d unchanged, %d skip, %d conflict\n",
+		counts[importActionCreate], counts[importActionUpdate], counts[importActionUnchanged],
+		counts[importActionSkip], counts[importActionConflict])
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importIn, "in", "", "Backup file to restore (required)")
+	importCmd.Flags().StringVar(&importRegion, "region", "", "AWS region (optional, default: from config, environment or backup)")
+	importCmd.Flags().StringVar(&importRole, "role", "", "AWS role ARN to assume (optional)")
+	importCmd.Flags().StringVar(&importOnConflict, "on-conflict", conflictFail, "Policy for existing parameters that differ: skip, overwrite or fail")
+}
@@ -0,0 +1,366 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+	"git.sr.ht/~wombelix/params2env/internal/backup"
+	"git.sr.ht/~wombelix/params2env/internal/config"
+	"git.sr.ht/~wombelix/params2env/internal/dotenv"
+	"git.sr.ht/~wombelix/params2env/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+// Command-line flags for the import-env command
+var (
+	// importEnvFile is the .env file to import
+	importEnvFile string
+	// importEnvPrefix is the parameter path the variables are imported below
+	importEnvPrefix string
+	// importEnvNaming is the rule that maps variable names to path segments
+	importEnvNaming string
+	// importEnvSecurePatterns are the variable name patterns stored as
+	// SecureString parameters
+	importEnvSecurePatterns []string
+	// importEnvKMS is the KMS key ID for SecureString parameters
+	importEnvKMS string
+	// importEnvRegion is the AWS region to import to
+	importEnvRegion string
+	// importEnvRole is the AWS IAM role to assume
+	importEnvRole string
+	// importEnvOnConflict is the policy for existing parameters that differ
+	importEnvOnConflict string
+)
+
+// Naming rules that map a variable name like DB_PASSWORD to a path segment
+const (
+	// namingLower lowercases the name: db_password
+	namingLower = "lower"
+	// namingKebab lowercases the name and replaces underscores: db-password
+	namingKebab = "kebab"
+	// namingPath lowercases the name and nests at underscores: db/password
+	namingPath = "path"
+	// namingKeep keeps the name unchanged: DB_PASSWORD
+	namingKeep = "keep"
+)
+
+// defaultSecurePatterns are the variable name patterns imported as
+// SecureString parameters by default
+var defaultSecurePatterns = []string{"*PASSWORD*", "*PASSWD*", "*SECRET*", "*TOKEN*", "*CREDENTIAL*", "*PRIVATE*", "*_KEY"}
+
+// importEnvCmd represents the import-env command
+var importEnvCmd = &cobra.Command{
+	Use:   "import-env",
+	Short: "Import the variables of a .env file as parameters",
+	Long: `Import the variables of a .env file as parameters.
+
+Every variable of the file is stored below the prefix. The variable name is
+mapped to a path segment with the naming rule:
+  lower  DB_PASSWORD -> <prefix>/db_password (default)
+  kebab  DB_PASSWORD -> <prefix>/db-password
+  path   DB_PASSWORD -> <prefix>/db/password
+  keep   DB_PASSWORD -> <prefix>/DB_PASSWORD
+
+Variables whose name matches one of the secure patterns are stored as
+SecureString, all others as String. Patterns are matched case-insensitive and
+support * and ? wildcards. Variables with an empty value are skipped, SSM
+doesn't support empty values. Values over 4 KB use the Advanced tier, values
+over 8 KB are rejected before anything is written.
+
+The file supports comments, export prefixes, single and double quotes,
+escapes in double quotes and quoted values spanning multiple lines.
+
+Existing parameters with a different value or type are handled by
+--on-conflict like in the import command. With --dry-run, the plan is printed
+without writing any parameter. Values are never printed.
+
+Examples:
+  # Review what would be imported
+  params2env --dry-run import-env --file .env --prefix /myapp/prod --kms alias/myapp
+
+  # Import nested paths and treat API_* variables as secrets
+  params2env import-env --file .env --prefix /myapp/prod --naming path \
+    --secure-pattern '*PASSWORD*' --secure-pattern 'API_*' --kms alias/myapp`,
+	PreRunE: validateImportEnvFlags,
+	RunE:    runImportEnv,
+}
+
+// validateImportEnvFlags checks if all required flags are set and valid
+func validateImportEnvFlags(cmd *cobra.Command, args []string) error {
+	if importEnvFile == "" {
+		return fmt.Errorf("required flag \"file\" not set")
+	}
+
+	if importEnvPrefix == "" {
+		return fmt.Errorf("required flag \"prefix\" not set")
+	}
+	if err := validation.ValidateParameterPath(importEnvPrefix); err != nil {
+		return err
+	}
+
+	switch importEnvNaming {
+	case namingLower, namingKebab, namingPath, namingKeep:
+	default:
+		return fmt.Errorf("invalid naming rule: %s (must be '%s', '%s', '%s' or '%s')",
+			importEnvNaming, namingLower, namingKebab, namingPath, namingKeep)
+	}
+
+	for _, pattern := range importEnvSecurePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid secure pattern: %s", pattern)
+		}
+	}
+
+	switch importEnvOnConflict {
+	case conflictSkip, conflictOverwrite, conflictFail:
+	default:
+		return fmt.Errorf("invalid conflict policy: %s (must be '%s', '%s' or '%s')",
+			importEnvOnConflict, conflictSkip, conflictOverwrite, conflictFail)
+	}
+
+	if err := validation.ValidateKMSKey(importEnvKMS); err != nil {
+		return err
+	}
+
+	if err := validation.ValidateRegion(importEnvRegion); err != nil {
+		return err
+	}
+
+	if err := validation.ValidateRoleARN(importEnvRole); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// runImportEnv executes the import-env command
+func runImportEnv(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Merge config with flags (flags take precedence)
+	mergeImportEnvConfig(cfg)
+
+	// Ensure region is set
+	if importEnvRegion == "" {
+		if importEnvRegion = os.Getenv("AWS_REGION"); importEnvRegion == "" {
+			return fmt.Errorf("AWS region must be specified via --region, config file, or AWS_REGION environment variable")
+		}
+	}
+
+	file, err := os.Open(importEnvFile)
+	if err != nil {
+		return fmt.Errorf("failed to read env file: %w", err)
+	}
+	defer file.Close()
+	entries, err := dotenv.Parse(file)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", importEnvFile, err)
+	}
+
+	params, err := envParameters(entries)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := aws.NewClient(ctx, importEnvRegion, importEnvRole)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	list, err := client.ListParameters(ctx, importEnvPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to read parameters below '%s' in region '%s': %w", importEnvPrefix, importEnvRegion, err)
+	}
+	existing := make(map[string]aws.Parameter, len(list))
+	for _, param := range list {
+		existing[param.Name] = param
+	}
+
+	plan := planImportEnv(params, existing, importEnvOnConflict)
+
+	if dryRun || hasImportConflicts(plan) {
+		fmt.Printf("Plan to import '%s' to '%s' in region '%s':\n", importEnvFile, importEnvPrefix, importEnvRegion)
+		for _, item := range plan {
+			printImportItem(item, "")
+		}
+		printImportSummary(plan)
+		if !dryRun {
+			return fmt.Errorf("parameters already exist with different values, use --on-conflict skip or overwrite to import, no parameters were changed")
+		}
+		return nil
+	}
+
+	fmt.Printf("Importing '%s' to '%s' in region '%s':\n", importEnvFile, importEnvPrefix, importEnvRegion)
+	var errs []error
+	for _, item := range plan {
+		if err := applyImportEnvItem(ctx, client, item); err != nil {
+			printImportItem(item, fmt.Sprintf("failed: %v", err))
+			errs = append(errs, fmt.Errorf("%s: %w", item.param.Name, err))
+			continue
+		}
+		printImportItem(item, "")
+	}
+	printImportSummary(plan)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to import %d parameter(s): %w", len(errs), errors.Join(errs...))
+	}
+	return nil
+}
+
+// mergeImportEnvConfig merges configuration from file with command line flags
+func mergeImportEnvConfig(cfg *config.Config) {
+	if cfg == nil {
+		return
+	}
+	if importEnvRegion == "" {
+		importEnvRegion = cfg.Region
+	}
+	if importEnvRole == "" {
+		importEnvRole = cfg.Role
+	}
+	if importEnvKMS == "" {
+		importEnvKMS = cfg.KMS
+	}
+}
+
+// envParameters maps the variables of a .env file to parameters. Two
+// variables that map to the same path are an error. Values above the
+// standard size limit use the advanced tier, values above the advanced
+// limit are an error.
+func envParameters(entries []dotenv.Entry) ([]backup.Parameter, error) {
+	params := make([]backup.Parameter, 0, len(entries))
+	keys := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		name := importEnvPrefix + "/" + envKeyToPath(entry.Key, importEnvNaming)
+		if err := validation.ValidateParameterPath(name); err != nil {
+			return nil, fmt.Errorf("variable %s on line %d: %w", entry.Key, entry.Line, err)
+		}
+		if other, ok := keys[name]; ok {
+			return nil, fmt.Errorf("variables %s and %s both map to parameter %s", other, entry.Key, name)
+		}
+		keys[name] = entry.Key
+
+		param := backup.Parameter{Name: name, Type: aws.ParameterTypeString, Value: entry.Value}
+		switch size := len(entry.Value); {
+		case size > aws.MaxAdvancedValueSize:
+			return nil, fmt.Errorf("variable %s on line %d: value of %d bytes exceeds the maximum of %d bytes", entry.Key, entry.Line, size, aws.MaxAdvancedValueSize)
+		case size > aws.MaxStandardValueSize:
+			param.Tier = aws.ParameterTierAdvanced
+		}
+		if isSecureKey(entry.Key, importEnvSecurePatterns) {
+			param.Type = aws.ParameterTypeSecureString
+			if err := validation.ValidateSecureStringRequirements(param.Type, importEnvKMS); err != nil {
+				return nil, fmt.Errorf("variable %s matches a secure pattern: %w", entry.Key, err)
+			}
+			param.KeyID = importEnvKMS
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// envKeyToPath maps a variable name to a parameter path segment with the
+// given naming rule
+func envKeyToPath(key, naming string) string {
+	switch naming {
+	case namingKeep:
+		return key
+	case namingKebab:
+		return strings.ReplaceAll(strings.ToLower(key), "_", "-")
+	case namingPath:
+		var segments []string
+		for _, segment := range strings.Split(strings.ToLower(key), "_") {
+			if segment != "" {
+				segments = append(segments, segment)
+			}
+		}
+		return strings.Join(segments, "/")
+	}
+	return strings.ToLower(key)
+}
+
+// isSecureKey reports whether a variable name matches one of the patterns,
+// ignoring case
+func isSecureKey(key string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToUpper(pattern), strings.ToUpper(key)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// planImportEnv compares the variables with the existing parameters and
+// returns the action per variable in file order. Only value and type are
+// compared, the description of an existing parameter is kept.
+func planImportEnv(params []backup.Parameter, existing map[string]aws.Parameter, onConflict string) []importItem {
+	plan := make([]importItem, 0, len(params))
+	for _, p := range params {
+		item := importItem{param: p}
+		current, ok := existing[p.Name]
+		switch {
+		case p.Value == "":
+			item.action = importActionSkip
+			item.reasons = []string{"empty value"}
+		case !ok:
+			item.action = importActionCreate
+		default:
+			item.param.Description = current.Description
+			desired := aws.Parameter{Name: p.Name, Value: p.Value, Type: p.Type, Description: current.Description}
+			item.reasons = compareParameters(desired, current)
+			switch {
+			case len(item.reasons) == 0:
+				item.action = importActionUnchanged
+			case onConflict == conflictOverwrite:
+				item.action = importActionUpdate
+			case onConflict == conflictSkip:
+				item.action = importActionSkip
+				item.reasons = append(item.reasons, "use --on-conflict overwrite to replace")
+			default:
+				item.action = importActionConflict
+			}
+		}
+		plan = append(plan, item)
+	}
+	return plan
+}
+
+// applyImportEnvItem writes a single plan entry
+func applyImportEnvItem(ctx context.Context, client *aws.Client, item importItem) error {
+	if item.action != importActionCreate && item.action != importActionUpdate {
+		return nil
+	}
+
+	p := item.param
+	var kmsKeyID *string
+	if p.KeyID != "" {
+		kmsKeyID = &p.KeyID
+	}
+	return client.CreateParameter(ctx, p.Name, p.Value, p.Description, p.Type, kmsKeyID, item.action == importActionUpdate, aws.WithTier(p.Tier))
+}
+
+func init() {
+	importEnvCmd.Flags().StringVar(&importEnvFile, "file", "", "The .env file to import (required)")
+	importEnvCmd.Flags().StringVar(&importEnvPrefix, "prefix", "", "Parameter path prefix to import below (required)")
+	importEnvCmd.Flags().StringVar(&importEnvNaming, "naming", namingLower, "Naming rule for path segments: lower, kebab, path or keep")
+	importEnvCmd.Flags().StringSliceVar(&importEnvSecurePatterns, "secure-pattern", defaultSecurePatterns, "Variable name patterns stored as SecureString")
+	importEnvCmd.Flags().StringVar(&importEnvKMS, "kms", "", "KMS key ID for SecureString parameters (optional, default: from config)")
+	importEnvCmd.Flags().StringVar(&importEnvRegion, "region", "", "AWS region (optional, default: from config or environment)")
+	importEnvCmd.Flags().StringVar(&importEnvRole, "role", "", "AWS role ARN to assume (optional)")
+	importEnvCmd.Flags().StringVar(&importEnvOnConflict, "on-conflict", conflictFail, "Policy for existing parameters that differ: skip, overwrite or fail")
+}
@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+)
+
+// setupImportEnvFlags resets the import-env command flags for testing
+func setupImportEnvFlags() {
+	importEnvCmd.ResetFlags()
+	importEnvCmd.Flags().StringVar(&importEnvFile, "file", "", "The .env file")
+	importEnvCmd.Flags().StringVar(&importEnvPrefix, "prefix", "", "Parameter path prefix")
+	importEnvCmd.Flags().StringVar(&importEnvNaming, "naming", namingLower, "Naming rule")
+	importEnvCmd.Flags().StringSliceVar(&importEnvSecurePatterns, "secure-pattern", defaultSecurePatterns, "Secure patterns")
+	importEnvCmd.Flags().StringVar(&importEnvKMS, "kms", "", "KMS key ID")
+	importEnvCmd.Flags().StringVar(&importEnvRegion, "region", "", "AWS region")
+	importEnvCmd.Flags().StringVar(&importEnvRole, "role", "", "AWS role ARN")
+	importEnvCmd.Flags().StringVar(&importEnvOnConflict, "on-conflict", conflictFail, "Conflict policy")
+	testRoot.AddCommand(importEnvCmd)
+}
+
+func TestEnvKeyToPath(t *testing.T) {
+	tests := []struct {
+		naming string
+		want   string
+	}{
+		{naming: namingLower, want: "db_password"},
+		{naming: namingKebab, want: "db-password"},
+		{naming: namingPath, want: "db/password"},
+		{naming: namingKeep, want: "DB__PASSWORD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.naming, func(t *testing.T) {
+			key := "DB_PASSWORD"
+			if tt.naming == namingKeep || tt.naming == namingPath {
+				key = "DB__PASSWORD"
+			}
+			if got := envKeyToPath(key, tt.naming); got != tt.want {
+				t.Errorf("envKeyToPath(%q, %q) = %q, want %q", key, tt.naming, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSecureKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: "DB_PASSWORD", want: true},
+		{key: "github_token", want: true},
+		{key: "STRIPE_SECRET_KEY", want: true},
+		{key: "SSH_KEY", want: true},
+		{key: "KEYCLOAK_URL", want: false},
+		{key: "PORT", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := isSecureKey(tt.key, defaultSecurePatterns); got != tt.want {
+				t.Errorf("isSecureKey(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunImportEnv(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+
+	envFile := filepath.Join(t.TempDir(), ".env")
+	content := "# App\nexport APP_URL=https://example.com\nDB_PASSWORD=\"s3cret\"\nCERT=\"line1\nline2\"\nEMPTY=\n"
+	if err := os.WriteFile(envFile, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write env file: %v", err)
+	}
+
+	existing := map[string]aws.Parameter{
+		"/app/app_url": {Name: "/app/app_url", Value: "https://old.example.com", Type: aws.ParameterTypeString, Description: "kept"},
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		existing map[string]aws.Parameter
+		wantPuts []string
+		errMsg   string
+	}{
+		{name: "import", wantPuts: []string{"/app/app_url", "/app/db_password", "/app/cert"}},
+		{name: "naming_path", args: []string{"--naming", "path"}, wantPuts: []string{"/app/app/url", "/app/db/password", "/app/cert"}},
+		{name: "dry_run", args: []string{"--dry-run"}},
+		{name: "conflict_fail", existing: existing, errMsg: "parameters already exist"},
+		{name: "conflict_skip", args: []string{"--on-conflict", "skip"}, existing: existing, wantPuts: []string{"/app/db_password", "/app/cert"}},
+		{name: "conflict_overwrite", args: []string{"--on-conflict", "overwrite"}, existing: existing, wantPuts: []string{"/app/app_url", "/app/db_password", "/app/cert"}},
+		{name: "secure_without_kms", args: []string{"--kms", ""}, errMsg: "KMS key is required"},
+		{name: "invalid_naming", args: []string{"--naming", "camel"}, errMsg: "invalid naming rule"},
+		{name: "invalid_pattern", args: []string{"--secure-pattern", "[A-"}, errMsg: "invalid secure pattern"},
+	}
+
+	t.Run("value_sizes", func(t *testing.T) {
+		sizedFile := filepath.Join(t.TempDir(), ".env")
+		write := func(content string) {
+			if err := os.WriteFile(sizedFile, []byte(content), 0600); err != nil {
+				t.Fatalf("Failed to write env file: %v", err)
+			}
+		}
+		params := map[string]aws.Parameter{}
+		store := newRegionStore(map[string]map[string]aws.Parameter{"eu-west-1": params})
+		store.install()
+		args := []string{"import-env", "--file", sizedFile, "--prefix", "/app", "--region", "eu-west-1"}
+
+		write("SMALL=a\nLARGE=" + strings.Repeat("x", aws.MaxStandardValueSize+1) + "\n")
+		setupImportEnvFlags()
+		testRoot.SetArgs(args)
+		if err := testRoot.Execute(); err != nil {
+			t.Fatalf("runImportEnv() error = %v", err)
+		}
+		if tier := params["/app/large"].Tier; tier != aws.ParameterTierAdvanced {
+			t.Errorf("large parameter tier = %q, want %q", tier, aws.ParameterTierAdvanced)
+		}
+		if tier := params["/app/small"].Tier; tier == aws.ParameterTierAdvanced {
+			t.Errorf("small parameter tier = %q, want default", tier)
+		}
+
+		store.puts = map[string][]string{}
+		write("FIRST=a\nHUGE=" + strings.Repeat("x", aws.MaxAdvancedValueSize+1) + "\n")
+		setupImportEnvFlags()
+		testRoot.SetArgs(args)
+		if err := testRoot.Execute(); err == nil || !strings.Contains(err.Error(), "exceeds the maximum") {
+			t.Fatalf("runImportEnv() error = %v, want size error", err)
+		}
+		if len(store.puts["eu-west-1"]) != 0 {
+			t.Errorf("runImportEnv() wrote %v, want nothing", store.puts["eu-west-1"])
+		}
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := map[string]aws.Parameter{}
+			for name, p := range tt.existing {
+				params[name] = p
+			}
+			store := newRegionStore(map[string]map[string]aws.Parameter{"eu-west-1": params})
+			store.install()
+
+			setupImportEnvFlags()
+			setupDryRunFlag()
+			args := []string{"import-env", "--file", envFile, "--prefix", "/app", "--region", "eu-west-1", "--kms", "alias/app"}
+			testRoot.SetArgs(append(args, tt.args...))
+			err := testRoot.Execute()
+			dryRun = false
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("runImportEnv() error = %v, want error containing %q", err, tt.errMsg)
+				}
+			} else if err != nil {
+				t.Fatalf("runImportEnv() error = %v", err)
+			}
+
+			if got := strings.Join(store.puts["eu-west-1"], ","); got != strings.Join(tt.wantPuts, ",") {
+				t.Fatalf("runImportEnv() wrote %q, want %q", got, strings.Join(tt.wantPuts, ","))
+			}
+			if len(tt.wantPuts) == 0 {
+				return
+			}
+			password := params[tt.wantPuts[len(tt.wantPuts)-2]]
+			if password.Type != aws.ParameterTypeSecureString || password.KeyID != "alias/app" || password.Value != "s3cret" {
+				t.Errorf("password parameter = %+v, want SecureString with alias/app", password)
+			}
+			if cert := params[tt.wantPuts[len(tt.wantPuts)-1]]; cert.Type != aws.ParameterTypeString || cert.Value != "line1\nline2" {
+				t.Errorf("cert parameter = %+v, want multiline String", cert)
+			}
+			if url, ok := tt.existing["/app/app_url"]; ok && params["/app/app_url"].Description != url.Description {
+				t.Errorf("description = %q, want it kept", params["/app/app_url"].Description)
+			}
+		})
+	}
+}
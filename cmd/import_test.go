@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+	"git.sr.ht/~wombelix/params2env/internal/backup"
+)
+
+// setupImportFlags resets the import command flags for testing
+func setupImportFlags() {
+	importCmd.ResetFlags()
+	importCmd.Flags().StringVar(&importIn, "in", "", "Backup file")
+	importCmd.Flags().StringVar(&importRegion, "region", "", "AWS region")
+	importCmd.Flags().StringVar(&importRole, "role", "", "AWS role ARN")
+	importCmd.Flags().StringVar(&importOnConflict, "on-conflict", conflictFail, "Conflict policy")
+	testRoot.AddCommand(importCmd)
+}
+
+// writeTestBackup writes a backup of two parameters below /app
+func writeTestBackup(t *testing.T, passphrase string) string {
+	t.Helper()
+	b := backup.New("/app", "eu-central-1", []backup.Parameter{
+		{Name: "/app/password", Type: aws.ParameterTypeSecureString, Value: "s3cret", KeyID: "alias/app", Tier: aws.ParameterTierAdvanced, Version: 2},
+		{Name: "/app/url", Type: aws.ParameterTypeString, Value: "https://example.com", Description: "Service URL", Tags: map[string]string{"team": "platform"}, Version: 4},
+	})
+	data, err := backup.Marshal(b, backup.FormatJSON)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if passphrase != "" {
+		if data, err = backup.Encrypt(data, passphrase); err != nil {
+			t.Fatalf("Encrypt() error = %v", err)
+		}
+	}
+	file := filepath.Join(t.TempDir(), "backup.json")
+	if err := os.WriteFile(file, data, 0600); err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+	return file
+}
+
+func TestRunImport(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+
+	conflicting := map[string]aws.Parameter{
+		"/app/url": {Name: "/app/url", Value: "https://old.example.com", Type: aws.ParameterTypeString, Description: "Service URL", Version: 7},
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		existing   map[string]aws.Parameter
+		passphrase string
+		wantPuts   []string
+		wantURL    string
+		errMsg     string
+	}{
+		{name: "restore", wantPuts: []string{"/app/password", "/app/url"}, wantURL: "https://example.com"},
+		{name: "encrypted", passphrase: "correct horse", wantPuts: []string{"/app/password", "/app/url"}, wantURL: "https://example.com"},
+		{name: "conflict_fail", existing: conflicting, errMsg: "parameters already exist", wantURL: "https://old.example.com"},
+		{name: "conflict_skip", args: []string{"--on-conflict", "skip"}, existing: conflicting, wantPuts: []string{"/app/password"}, wantURL: "https://old.example.com"},
+		{name: "conflict_overwrite", args: []string{"--on-conflict", "overwrite"}, existing: conflicting, wantPuts: []string{"/app/password", "/app/url"}, wantURL: "https://example.com"},
+		{name: "dry_run", args: []string{"--dry-run", "--on-conflict", "overwrite"}, existing: conflicting, wantURL: "https://old.example.com"},
+		{name: "invalid_policy", args: []string{"--on-conflict", "merge"}, errMsg: "invalid conflict policy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := map[string]aws.Parameter{}
+			for name, p := range tt.existing {
+				params[name] = p
+			}
+			store := newRegionStore(map[string]map[string]aws.Parameter{"eu-west-1": params})
+			store.install()
+			t.Setenv(passphraseEnv, tt.passphrase)
+			file := writeTestBackup(t, tt.passphrase)
+
+			setupImportFlags()
+			setupDryRunFlag()
+			testRoot.SetArgs(append([]string{"import", "--in", file, "--region", "eu-west-1"}, tt.args...))
+			err := testRoot.Execute()
+			dryRun = false
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("runImport() error = %v, want error containing %q", err, tt.errMsg)
+				}
+			} else if err != nil {
+				t.Fatalf("runImport() error = %v", err)
+			}
+
+			if got := strings.Join(store.puts["eu-west-1"], ","); got != strings.Join(tt.wantPuts, ",") {
+				t.Errorf("runImport() wrote %q, want %q", got, strings.Join(tt.wantPuts, ","))
+			}
+			if tt.wantURL != "" && params["/app/url"].Value != tt.wantURL {
+				t.Errorf("/app/url = %q, want %q", params["/app/url"].Value, tt.wantURL)
+			}
+			if len(tt.wantPuts) == 0 {
+				return
+			}
+			password := params["/app/password"]
+			if password.Type != aws.ParameterTypeSecureString || password.KeyID != "alias/app" || password.Tier != aws.ParameterTierAdvanced {
+				t.Errorf("imported password = %+v", password)
+			}
+			if tags := store.tags["eu-west-1"]["/app/url"]; tt.wantURL == "https://example.com" && tags["team"] != "platform" {
+				t.Errorf("tag team = %q, want %q", tags["team"], "platform")
+			}
+		})
+	}
+}
+
+func TestReadBackupWrongPassphrase(t *testing.T) {
+	file := writeTestBackup(t, "correct horse")
+	t.Setenv(passphraseEnv, "wrong")
+	if _, err := readBackup(file); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Errorf("readBackup() error = %v, want wrong passphrase", err)
+	}
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// kmsKeyIDForRegion returns the KMS key ID to use when a parameter encrypted
+// with kmsKeyID is written to another region, e.g. a replica region or the
+// destination of a sync. An explicit mapping for the region in kmsKeys takes
+// precedence, otherwise the key is translated with getReplicaKMSKeyID.
+// Returns nil if no key is set.
+func kmsKeyIDForRegion(kmsKeyID, region string, kmsKeys map[string]string) (*string, error) {
+	if key, ok := kmsKeys[region]; ok && key != "" {
+		return &key, nil
+	}
+	if kmsKeyID == "" {
+		return nil, nil
+	}
+	return getReplicaKMSKeyID(kmsKeyID, region)
+}
+
+// getReplicaKMSKeyID returns the KMS key ID for the replica region with proper ARN validation.
+// For KMS key aliases or key IDs, returns the input unchanged.
+// For KMS ARNs, validates the format and constructs a new ARN for the replica region.
+func getReplicaKMSKeyID(kmsKeyID, replicaRegion string) (*string, error) {
+	// Check if it looks like an ARN (starts with "arn:")
+	if !strings.HasPrefix(kmsKeyID, "arn:") {
+		// Not an ARN, treat as alias or key ID
+		return &kmsKeyID, nil
+	}
+
+	// Parse ARN with validation
+	arnParts := strings.Split(kmsKeyID, ":")
+	if len(arnParts) != 6 {
+		return nil, fmt.Errorf("invalid KMS ARN format: %s", kmsKeyID)
+	}
+
+	if arnParts[0] != "arn" || arnParts[1] != "aws" || arnParts[2] != "kms" {
+		return nil, fmt.Errorf("invalid KMS ARN format: %s", kmsKeyID)
+	}
+
+	accountID := arnParts[4]
+	if accountID == "" {
+		return nil, fmt.Errorf("missing account ID in KMS ARN: %s", kmsKeyID)
+	}
+
+	keyPart := arnParts[5]
+	if !strings.HasPrefix(keyPart, "key/") {
+		return nil, fmt.Errorf("invalid key format in KMS ARN: %s", kmsKeyID)
+	}
+
+	keyID := strings.TrimPrefix(keyPart, "key/")
+	if keyID == "" {
+		return nil, fmt.Errorf("missing key ID in KMS ARN: %s", kmsKeyID)
+	}
+
+	replicaARN := fmt.Sprintf("arn:aws:kms:%s:%s:key/%s", replicaRegion, accountID, keyID)
+	return &replicaARN, nil
+}
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"git.sr.ht/~wombelix/params2env/internal/config"
+)
+
+// Markers of the block that read --merge manages in an existing file
+const (
+	mergeBeginMarker = "# BEGIN params2env managed block, do not edit"
+	mergeEndMarker   = "# END params2env managed block"
+)
+
+// mergeDisabledPrefix comments out assignments outside of the managed block
+// to variables the block sets, so the old value can't win
+const mergeDisabledPrefix = "# Disabled by params2env, set in the managed block: "
+
+// mergeAssignmentPattern matches a variable assignment in the shell, docker
+// and systemd formats and captures the variable name
+var mergeAssignmentPattern = regexp.MustCompile(`^\s*(?:export\s+)?([A-Za-z_][A-Za-z0-9_]*)=`)
+
+// mergeFormats are the output formats that can be merged into an existing
+// file, they are line based and support # comments
+var mergeFormats = []string{config.OutputShell, config.OutputDocker, config.OutputSystemd}
+
+// validateMergeFormat checks if output in the given format can be merged
+func validateMergeFormat(format string) error {
+	if !slices.Contains(mergeFormats, format) {
+		return fmt.Errorf("the %s format can't be merged (must be one of %s)", format, strings.Join(mergeFormats, ", "))
+	}
+	return nil
+}
+
+// mergeManagedBlock replaces the managed block in existing with output.
+// Assignments outside of the block to a variable the block sets are
+// commented out, all other lines are kept as they are. Variables that a
+// previous run wrote and this run doesn't are gone with the old block. If
+// there is no block yet, it's appended.
+//
+// Parameters:
+//   - existing: The current content of the file
+//   - output: The rendered variables
+//
+// Returns:
+//   - The merged content
+//   - The variables whose assignments outside of the block were commented out
+//   - An error if the block in existing isn't terminated
+func mergeManagedBlock(existing, output string) (string, []string, error) {
+	block := mergeBeginMarker + "\n" + output
+	if output != "" && !strings.HasSuffix(output, "\n") {
+		block += "\n"
+	}
+	block += mergeEndMarker + "\n"
+
+	lines := strings.SplitAfter(existing, "\n")
+	begin, end := -1, -1
+	for i, line := range lines {
+		trimmed := strings.TrimRight(line, "\r\n")
+		if begin == -1 && trimmed == mergeBeginMarker {
+			begin = i
+		} else if begin != -1 && trimmed == mergeEndMarker {
+			end = i
+			break
+		}
+	}
+
+	if begin != -1 && end == -1 {
+		return "", nil, fmt.Errorf("managed block isn't terminated by '%s'", mergeEndMarker)
+	}
+
+	managed := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		if m := mergeAssignmentPattern.FindStringSubmatch(line); m != nil {
+			managed[m[1]] = true
+		}
+	}
+	var disabled []string
+	for i, line := range lines {
+		if begin != -1 && i >= begin && i <= end {
+			continue
+		}
+		if m := mergeAssignmentPattern.FindStringSubmatch(line); m != nil && managed[m[1]] {
+			lines[i] = mergeDisabledPrefix + line
+			if !slices.Contains(disabled, m[1]) {
+				disabled = append(disabled, m[1])
+			}
+		}
+	}
+
+	if begin == -1 {
+		existing = strings.Join(lines, "")
+		if existing != "" && !strings.HasSuffix(existing, "\n") {
+			existing += "\n"
+		}
+		return existing + block, disabled, nil
+	}
+
+	before := strings.Join(lines[:begin], "")
+	after := strings.Join(lines[end+1:], "")
+	return before + block + after, disabled, nil
+}
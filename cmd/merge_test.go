@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestMergeManagedBlock(t *testing.T) {
+	block := func(output string) string {
+		return mergeBeginMarker + "\n" + output + mergeEndMarker + "\n"
+	}
+
+	tests := []struct {
+		name     string
+		existing string
+		output   string
+		want     string
+		disabled []string
+		errMsg   string
+	}{
+		{
+			name:   "empty_file",
+			output: "A=1\n",
+			want:   block("A=1\n"),
+		},
+		{
+			name:     "append",
+			existing: "# other tool\nOTHER=x",
+			output:   "A=1\n",
+			want:     "# other tool\nOTHER=x\n" + block("A=1\n"),
+		},
+		{
+			name:     "replace",
+			existing: "OTHER=x\n" + block("A=1\nOLD=2\n") + "# trailing\nLAST=y\n",
+			output:   "A=3\n",
+			want:     "OTHER=x\n" + block("A=3\n") + "# trailing\nLAST=y\n",
+		},
+		{
+			name:     "replace_crlf",
+			existing: "OTHER=x\r\n" + mergeBeginMarker + "\r\nA=1\r\n" + mergeEndMarker + "\r\n",
+			output:   "A=2\n",
+			want:     "OTHER=x\r\n" + block("A=2\n"),
+		},
+		{
+			name:     "disable_managed_keys_outside",
+			existing: "export A=\"old\"\nOTHER=x\n" + block("B=1\n") + "  A=stale\n",
+			output:   "export A=\"1\"\nB=2\n",
+			want: mergeDisabledPrefix + "export A=\"old\"\nOTHER=x\n" + block("export A=\"1\"\nB=2\n") +
+				mergeDisabledPrefix + "  A=stale\n",
+			disabled: []string{"A"},
+		},
+		{
+			name:     "disable_on_first_merge",
+			existing: "DB_URL=old\n# DB_URL=comment\n",
+			output:   "DB_URL=new\n",
+			want:     mergeDisabledPrefix + "DB_URL=old\n# DB_URL=comment\n" + block("DB_URL=new\n"),
+			disabled: []string{"DB_URL"},
+		},
+		{
+			name:     "unterminated",
+			existing: mergeBeginMarker + "\nA=1\n",
+			output:   "A=2\n",
+			errMsg:   "isn't terminated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, disabled, err := mergeManagedBlock(tt.existing, tt.output)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("mergeManagedBlock() error = %v, want error containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("mergeManagedBlock() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("mergeManagedBlock() =\n%q\nwant\n%q", got, tt.want)
+			}
+			if !slices.Equal(disabled, tt.disabled) {
+				t.Errorf("mergeManagedBlock() disabled = %v, want %v", disabled, tt.disabled)
+			}
+		})
+	}
+}
+
+func TestRunReadMerge(t *testing.T) {
+	rts := setupReadTest(t)
+	defer rts.cleanup()
+	defer func() { readFormat = "shell" }()
+
+	file := filepath.Join(rts.tmpDir, "app.env")
+	existing := "# Written by hand\nexport OTHER=\"x\"\n" + mergeBeginMarker + "\nexport GONE=\"old\"\n" + mergeEndMarker + "\n"
+	if err := os.WriteFile(file, []byte(existing), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	merged := "# Written by hand\nexport OTHER=\"x\"\n" + mergeBeginMarker + "\nexport URL=\"test-value\"\n" + mergeEndMarker + "\n"
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     string
+		wantMode os.FileMode
+		errMsg   string
+	}{
+		{
+			name:     "merge_keeps_mode",
+			args:     []string{"--file", file},
+			want:     merged,
+			wantMode: 0644,
+		},
+		{
+			name:     "merge_with_file_mode",
+			args:     []string{"--file", file, "--file-mode", "0640"},
+			want:     merged,
+			wantMode: 0640,
+		},
+		{name: "without_file", errMsg: "requires a file"},
+		{name: "k8s", args: []string{"--file", file, "--format", "k8s"}, errMsg: "the k8s format can't be merged"},
+		{name: "output_dir", args: []string{"--output-dir", rts.tmpDir}, errMsg: "mutually exclusive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testRoot := &cobra.Command{Use: "params2env"}
+			setupReadFlags(t, testRoot)
+
+			oldStdout := os.Stdout
+			devNull, _ := os.Open(os.DevNull)
+			os.Stdout = devNull
+			testRoot.SetArgs(append([]string{"read", "--path", "/app/url", "--merge"}, tt.args...))
+			err := testRoot.Execute()
+			os.Stdout = oldStdout
+			_ = devNull.Close()
+
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("runRead() error = %v, want error containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runRead() error = %v", err)
+			}
+			content, _ := os.ReadFile(file)
+			if string(content) != tt.want {
+				t.Errorf("file =\n%q\nwant\n%q", content, tt.want)
+			}
+			info, err := os.Stat(file)
+			if err != nil {
+				t.Fatalf("Failed to stat file: %v", err)
+			}
+			if perm := info.Mode().Perm(); perm != tt.wantMode {
+				t.Errorf("file mode = %o, want %o", perm, tt.wantMode)
+			}
+		})
+	}
+}
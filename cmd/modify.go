@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"git.sr.ht/~wombelix/params2env/internal/aws"
 	"git.sr.ht/~wombelix/params2env/internal/config"
@@ -22,14 +23,34 @@ var (
 	modifyPath string
 	// modifyValue is the new value to assign to the parameter
 	modifyValue string
+	// modifyValueFile is a file to read the new value from
+	modifyValueFile string
+	// modifyValueStdin reads the new value from the standard input
+	modifyValueStdin bool
+	// modifyTrim removes surrounding whitespace from a value read from a file or stdin
+	modifyTrim bool
 	// modifyDesc is the new description for the parameter
 	modifyDesc string
 	// modifyRegion is the AWS region where the parameter will be modified
 	modifyRegion string
 	// modifyRole is the AWS IAM role to assume for the operation
 	modifyRole string
-	// modifyReplica is the region where the parameter replica should be modified
-	modifyReplica string
+	// modifyReplicas are the regions where the parameter replicas should be modified
+	modifyReplicas []string
+	// modifyAtomic restores the prior state of all regions if any region fails
+	modifyAtomic bool
+	// modifyType is the new parameter type, empty to keep the current type
+	modifyType string
+	// modifyKMS is the new KMS key ID for SecureString parameters
+	modifyKMS string
+	// modifyReplicaKMS maps a replica region to its KMS key ID
+	modifyReplicaKMS map[string]string
+	// modifyForce writes the parameter even if it is unchanged
+	modifyForce bool
+	// modifyExpectVersion is the version the parameter must have, 0 to skip the check
+	modifyExpectVersion int64
+	// modifyExpectHash is the SHA-256 hash the current value must have, empty to skip the check
+	modifyExpectHash string
 )
 
 // modifyCmd represents the modify command
@@ -39,17 +60,46 @@ var modifyCmd = &cobra.Command{
 	Long: `Modify an existing parameter in SSM Parameter Store.
 
 The parameter will be updated with the specified value.
-Optionally, you can update the description.
+Optionally, you can update the description, the type and the KMS key.
+The version history of the parameter is kept.
+
+The value can also be read with --value-file or --value-stdin, the bytes are
+used as they are unless --trim is set. Without any value flag, the value is
+prompted for on a terminal without echo, SecureString values have to be
+entered twice.
+
+Setting --kms implies --type SecureString. A SecureString parameter
+always requires a KMS key, either via --kms or the kms config setting.
+
+With --expect-version or --expect-value-hash, the parameter is only modified
+if its current version or the SHA-256 hash of its current value in the
+primary region matches, otherwise the command fails with a version conflict.
 
 Examples:
   # Modify a parameter's value
   params2env modify --path /myapp/config/url --value https://newexample.com
 
+  # Modify a parameter's value read from stdin
+  generate-token | params2env modify --path /myapp/secrets/token --value-stdin --trim
+
   # Modify a parameter's value and description
   params2env modify --path /myapp/config/url --value https://newexample.com --description "Updated URL"
 
-  # Modify a parameter and its replica
-  params2env modify --path /myapp/config/url --value https://newexample.com --replica us-west-2`,
+  # Modify a parameter and its replicas
+  params2env modify --path /myapp/config/url --value https://newexample.com --replica us-west-2,eu-west-1
+
+  # Convert a String parameter to a SecureString parameter
+  params2env modify --path /myapp/secrets/token --value mysecret --type SecureString --kms alias/mykey
+
+  # Rotate the KMS key of a parameter and its replicas
+  params2env modify --path /myapp/secrets/token --value mysecret --kms alias/newkey \
+    --replica eu-west-1 --replica-kms eu-west-1=alias/eu-newkey
+
+  # Modify a parameter only if nobody else changed it since version 4
+  params2env modify --path /myapp/config/url --value https://newexample.com --expect-version 4
+
+  # Modify a parameter in all regions or in none of them
+  params2env modify --path /myapp/config/url --value https://newexample.com --replica us-west-2 --atomic`,
 	PreRunE: validateModifyFlags,
 	RunE:    runModify,
 }
@@ -63,22 +113,49 @@ func validateModifyFlags(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if modifyValue == "" {
-		return fmt.Errorf("required flag \"value\" not set")
+	if err := validateValueSources(modifyValue != "", modifyValueFile, modifyValueStdin); err != nil {
+		return err
 	}
 
 	if err := validation.ValidateRegion(modifyRegion); err != nil {
 		return err
 	}
 
-	if err := validation.ValidateRegion(modifyReplica); err != nil {
-		return fmt.Errorf("invalid replica region: %w", err)
+	for _, replica := range modifyReplicas {
+		if err := validation.ValidateRegion(replica); err != nil {
+			return fmt.Errorf("invalid replica region: %w", err)
+		}
 	}
 
 	if err := validation.ValidateRoleARN(modifyRole); err != nil {
 		return err
 	}
 
+	if modifyType != "" && !aws.IsValidParameterType(modifyType) {
+		return fmt.Errorf("invalid parameter type: %s (must be '%s', '%s' or '%s')",
+			modifyType, aws.ParameterTypeString, aws.ParameterTypeStringList, aws.ParameterTypeSecureString)
+	}
+
+	if err := validation.ValidateKMSKey(modifyKMS); err != nil {
+		return err
+	}
+	if modifyKMS != "" && modifyType != "" && modifyType != aws.ParameterTypeSecureString {
+		return fmt.Errorf("KMS key can only be set for %s parameters", aws.ParameterTypeSecureString)
+	}
+
+	for region, key := range modifyReplicaKMS {
+		if err := validation.ValidateRegion(region); err != nil {
+			return fmt.Errorf("invalid replica KMS region: %w", err)
+		}
+		if err := validation.ValidateKMSKey(key); err != nil {
+			return err
+		}
+	}
+
+	if err := validateExpectations(modifyExpectVersion, modifyExpectHash); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -98,26 +175,106 @@ func runModify(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Validate regions are different
-	if err := validation.ValidateRegions(modifyRegion, modifyReplica); err != nil {
+	// Validate replica regions differ from the primary region and each other
+	if err := validation.ValidateReplicaRegions(modifyRegion, modifyReplicas); err != nil {
 		return err
 	}
 
-	// Modify parameter in primary region
-	if err := modifyInPrimaryRegion(); err != nil {
+	// A KMS key is only used by SecureString parameters
+	if modifyKMS != "" && modifyType == "" {
+		modifyType = aws.ParameterTypeSecureString
+	}
+
+	// Validate SecureString requirements
+	if err := validation.ValidateSecureStringRequirements(modifyType, modifyKMS); err != nil {
 		return err
 	}
 
-	// Handle replica if specified
-	if modifyReplica != "" {
-		if err := modifyInReplicaRegion(); err != nil {
+	// Read the value if it isn't passed via --value
+	if modifyValue == "" {
+		if modifyValue, err = readModifyValue(); err != nil {
 			return err
 		}
 	}
 
+	if err := validateModifyListValue(); err != nil {
+		return err
+	}
+
+	// Refuse to modify a parameter that changed since it was read
+	if err := checkExpectations(modifyRegion, modifyRole, modifyPath, modifyExpectVersion, modifyExpectHash); err != nil {
+		return err
+	}
+
+	if dryRun {
+		return runPlan(modifyPath, append([]string{modifyRegion}, modifyReplicas...), planModifyInRegion)
+	}
+
+	if modifyAtomic {
+		return runAtomic("modify", modifyPath, modifyRole, modifyRegion, modifyReplicas, modifyInPrimaryRegion, modifyInReplicaRegion)
+	}
+
+	// Modify parameter in primary region
+	if err := modifyInPrimaryRegion(); err != nil && !errors.Is(err, errUnchanged) {
+		return err
+	}
+
+	// Handle replicas if specified
+	if len(modifyReplicas) > 0 {
+		results := runInRegions(modifyReplicas, modifyInReplicaRegion)
+		return reportRegionResults("modify", modifyPath, results)
+	}
+
 	return nil
 }
 
+// readModifyValue reads the value from --value-file or --value-stdin, or
+// prompts for it. The value has to be confirmed if the parameter is or
+// becomes a SecureString.
+func readModifyValue() (string, error) {
+	if modifyValueFile != "" || modifyValueStdin {
+		return readValue(modifyValueFile, modifyValueStdin, modifyTrim)
+	}
+
+	secure := modifyType == aws.ParameterTypeSecureString
+	if modifyType == "" {
+		current, err := currentParameter(modifyRegion, modifyRole, modifyPath)
+		if err != nil {
+			return "", err
+		}
+		secure = current != nil && current.Type == aws.ParameterTypeSecureString
+	}
+	return promptValue(modifyPath, secure)
+}
+
+// validateModifyListValue validates the items of the value if the parameter
+// is or becomes a StringList, like the create command does. Without --type,
+// the current type in the primary region is used.
+func validateModifyListValue() error {
+	paramType := modifyType
+	if paramType == "" {
+		ctx := context.Background()
+		client, err := aws.NewClient(ctx, modifyRegion, modifyRole)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+		current, err := client.GetParameterInfo(ctx, modifyPath)
+		if err != nil {
+			// A missing parameter is reported by the write
+			if errors.Is(err, aws.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		paramType = current.Type
+	}
+
+	if paramType != aws.ParameterTypeStringList {
+		return nil
+	}
+	return validation.ValidateStringListItems(strings.Split(modifyValue, ","))
+}
+
 // mergeModifyConfig merges configuration from file with command line flags
 func mergeModifyConfig(cfg *config.Config) {
 	if cfg == nil {
@@ -126,12 +283,23 @@ func mergeModifyConfig(cfg *config.Config) {
 	if modifyRegion == "" {
 		modifyRegion = cfg.Region
 	}
-	if modifyReplica == "" {
-		modifyReplica = cfg.Replica
+	if len(modifyReplicas) == 0 {
+		modifyReplicas = cfg.ReplicaRegions()
 	}
 	if modifyRole == "" {
 		modifyRole = cfg.Role
 	}
+	if modifyKMS == "" && modifyType == aws.ParameterTypeSecureString {
+		modifyKMS = cfg.KMS
+	}
+	for region, key := range cfg.KMSKeys {
+		if _, ok := modifyReplicaKMS[region]; !ok {
+			if modifyReplicaKMS == nil {
+				modifyReplicaKMS = make(map[string]string)
+			}
+			modifyReplicaKMS[region] = key
+		}
+	}
 }
 
 // ensureModifyRegionIsSet ensures AWS region is set from flags, config, or environment
@@ -144,7 +312,8 @@ func ensureModifyRegionIsSet() error {
 	return nil
 }
 
-// modifyInPrimaryRegion modifies the parameter in the primary region
+// modifyInPrimaryRegion modifies the parameter in the primary region. It
+// returns errUnchanged if the write was skipped.
 func modifyInPrimaryRegion() error {
 	ctx := context.Background()
 	client, err := aws.NewClient(ctx, modifyRegion, modifyRole)
@@ -152,7 +321,27 @@ func modifyInPrimaryRegion() error {
 		return fmt.Errorf("failed to create AWS client: %w", err)
 	}
 
-	if err := client.ModifyParameter(ctx, modifyPath, modifyValue, modifyDesc); err != nil {
+	var kmsKeyID *string
+	if modifyKMS != "" {
+		kmsKeyID = &modifyKMS
+	}
+
+	if !modifyForce {
+		desired, err := modifyDesired(modifyRegion)
+		if err != nil {
+			return err
+		}
+		unchanged, err := isUnchanged(modifyRegion, modifyRole, desired)
+		if err != nil {
+			return err
+		}
+		if unchanged {
+			fmt.Printf("Parameter '%s' is unchanged in region '%s'\n", modifyPath, modifyRegion)
+			return errUnchanged
+		}
+	}
+
+	if err := client.ModifyParameter(ctx, modifyPath, modifyValue, modifyDesc, modifyType, kmsKeyID); err != nil {
 		if errors.Is(err, aws.ErrNotFound) {
 			return fmt.Errorf("parameter '%s' not found in region '%s'", modifyPath, modifyRegion)
 		}
@@ -163,36 +352,107 @@ func modifyInPrimaryRegion() error {
 	return nil
 }
 
-// modifyInReplicaRegion modifies the parameter in the replica region
-func modifyInReplicaRegion() error {
+// modifyInReplicaRegion modifies the parameter in the given replica region
+func modifyInReplicaRegion(replica string) error {
 	ctx := context.Background()
-	replicaClient, err := aws.NewClient(ctx, modifyReplica, modifyRole)
+	replicaClient, err := aws.NewClient(ctx, replica, modifyRole)
 	if err != nil {
 		return fmt.Errorf("failed to create AWS client for replica region: %w", err)
 	}
 
-	if err := replicaClient.ModifyParameter(ctx, modifyPath, modifyValue, modifyDesc); err != nil {
+	replicaKMSKeyID, err := modifyKMSKeyIDFor(replica)
+	if err != nil {
+		return fmt.Errorf("failed to process KMS key for replica region: %w", err)
+	}
+
+	if !modifyForce {
+		desired, err := modifyDesired(replica)
+		if err != nil {
+			return err
+		}
+		unchanged, err := isUnchanged(replica, modifyRole, desired)
+		if err != nil {
+			return err
+		}
+		if unchanged {
+			return errUnchanged
+		}
+	}
+
+	if err := replicaClient.ModifyParameter(ctx, modifyPath, modifyValue, modifyDesc, modifyType, replicaKMSKeyID); err != nil {
 		if errors.Is(err, aws.ErrNotFound) {
-			return fmt.Errorf("parameter '%s' not found in replica region '%s'", modifyPath, modifyReplica)
+			return fmt.Errorf("parameter '%s' not found in replica region '%s'", modifyPath, replica)
 		}
 		return fmt.Errorf("failed to modify parameter in replica region: %w", err)
 	}
 
-	fmt.Printf("Successfully modified parameter '%s' in replica region '%s'\n", modifyPath, modifyReplica)
 	return nil
 }
 
+// planModifyInRegion returns the change modify would make in the given region
+func planModifyInRegion(region string) (regionPlan, error) {
+	current, err := currentParameter(region, modifyRole, modifyPath)
+	if err != nil {
+		return regionPlan{}, err
+	}
+	if current == nil {
+		return regionPlan{action: planActionFail, details: []string{"parameter not found"}}, nil
+	}
+
+	desired, err := modifyDesired(region)
+	if err != nil {
+		return regionPlan{}, err
+	}
+	return planWrite(current, desired, true), nil
+}
+
+// modifyDesired returns the parameter modify writes to the given region.
+// Empty fields are kept unchanged.
+func modifyDesired(region string) (aws.Parameter, error) {
+	desired := aws.Parameter{Name: modifyPath, Value: modifyValue, Description: modifyDesc, Type: modifyType}
+	keyID, err := modifyKMSKeyIDFor(region)
+	if err != nil {
+		return desired, fmt.Errorf("failed to process KMS key for replica region: %w", err)
+	}
+	if keyID != nil {
+		desired.KeyID = *keyID
+	}
+	return desired, nil
+}
+
+// modifyKMSKeyIDFor returns the KMS key ID to set in the given region,
+// nil if the KMS key isn't changed
+func modifyKMSKeyIDFor(region string) (*string, error) {
+	if modifyType != aws.ParameterTypeSecureString {
+		return nil, nil
+	}
+	if region == modifyRegion {
+		if modifyKMS == "" {
+			return nil, nil
+		}
+		return &modifyKMS, nil
+	}
+	return kmsKeyIDForRegion(modifyKMS, region, modifyReplicaKMS)
+}
+
 func init() {
 	modifyCmd.Flags().StringVar(&modifyPath, "path", "", "Parameter path (required)")
 	modifyCmd.Flags().StringVar(&modifyValue, "value", "", "Parameter value (required)")
+	modifyCmd.Flags().StringVar(&modifyValueFile, "value-file", "", "Read the parameter value from a file")
+	modifyCmd.Flags().BoolVar(&modifyValueStdin, "value-stdin", false, "Read the parameter value from stdin")
+	modifyCmd.Flags().BoolVar(&modifyTrim, "trim", false, "Remove surrounding whitespace from a value read from a file or stdin")
 	modifyCmd.Flags().StringVar(&modifyDesc, "description", "", "Parameter description")
 	modifyCmd.Flags().StringVar(&modifyRegion, "region", "", "AWS region (optional, default: from AWS config or environment)")
 	modifyCmd.Flags().StringVar(&modifyRole, "role", "", "AWS role ARN to assume (optional)")
-	modifyCmd.Flags().StringVar(&modifyReplica, "replica", "", "Region to replicate the parameter to")
+	modifyCmd.Flags().StringSliceVar(&modifyReplicas, "replica", nil, "Regions to replicate the parameter to (repeatable or comma separated)")
+	modifyCmd.Flags().BoolVar(&modifyAtomic, "atomic", false, "Roll back all regions if any region fails")
+	modifyCmd.Flags().StringVar(&modifyType, "type", "", "New parameter type (String, StringList or SecureString)")
+	modifyCmd.Flags().StringVar(&modifyKMS, "kms", "", "New KMS key ID for SecureString parameters")
+	modifyCmd.Flags().StringToStringVar(&modifyReplicaKMS, "replica-kms", nil, "KMS key ID per replica region (region=key)")
+	modifyCmd.Flags().BoolVar(&modifyForce, "force", false, "Write the parameter even if it is unchanged")
+	modifyCmd.Flags().Int64Var(&modifyExpectVersion, "expect-version", 0, "Only modify if the parameter has this version in the primary region")
+	modifyCmd.Flags().StringVar(&modifyExpectHash, "expect-value-hash", "", "Only modify if the current value has this SHA-256 hash in the primary region")
 	if err := modifyCmd.MarkFlagRequired("path"); err != nil {
 		panic(err)
 	}
-	if err := modifyCmd.MarkFlagRequired("value"); err != nil {
-		panic(err)
-	}
 }
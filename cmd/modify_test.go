@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"git.sr.ht/~wombelix/params2env/internal/aws"
 	"git.sr.ht/~wombelix/params2env/internal/config"
 )
 
@@ -138,7 +139,10 @@ func TestModifyInputValidation(t *testing.T) {
 			modifyPath = tt.path
 			modifyValue = tt.value
 			modifyRegion = tt.region
-			modifyReplica = tt.replica
+			modifyReplicas = nil
+			if tt.replica != "" {
+				modifyReplicas = []string{tt.replica}
+			}
 			modifyRole = tt.role
 
 			// Test validation function directly (focuses on input validation only)
@@ -203,3 +207,170 @@ func TestRunModifyWithConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestModifyTypeAndKMS(t *testing.T) {
+	ts := setupTest(t)
+	t.Cleanup(ts.cleanup)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  bool
+		wantType string
+		// wantKeys is the expected KMS key per region
+		wantKeys map[string]string
+	}{
+		{
+			name:     "convert_to_secure_string",
+			args:     []string{"--type", "SecureString", "--kms", "alias/app", "--replica-kms", "eu-west-1=alias/eu-app"},
+			wantType: aws.ParameterTypeSecureString,
+			wantKeys: map[string]string{"us-west-2": "alias/app", "eu-west-1": "alias/eu-app"},
+		},
+		{
+			name:     "kms_implies_secure_string",
+			args:     []string{"--kms", "alias/app"},
+			wantType: aws.ParameterTypeSecureString,
+			wantKeys: map[string]string{"us-west-2": "alias/app", "eu-west-1": "alias/app"},
+		},
+		{
+			name:     "keep_type",
+			wantType: aws.ParameterTypeString,
+			wantKeys: map[string]string{"us-west-2": "", "eu-west-1": ""},
+		},
+		{
+			name:    "secure_string_without_kms",
+			args:    []string{"--type", "SecureString"},
+			wantErr: true,
+		},
+		{
+			name:    "kms_with_string_type",
+			args:    []string{"--type", "String", "--kms", "alias/app"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid_type",
+			args:    []string{"--type", "Invalid"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := map[string]map[string]aws.Parameter{
+				"us-west-2": {"/app/a": {Name: "/app/a", Value: "old", Type: aws.ParameterTypeString, Version: 1}},
+				"eu-west-1": {"/app/a": {Name: "/app/a", Value: "old", Type: aws.ParameterTypeString, Version: 1}},
+			}
+			newRegionStore(params).install()
+
+			setupModifyFlags()
+			testRoot.AddCommand(modifyCmd)
+			args := []string{"modify", "--path", "/app/a", "--value", "new", "--region", "us-west-2", "--replica", "eu-west-1"}
+			testRoot.SetArgs(append(args, tt.args...))
+			err := testRoot.Execute()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runModify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for region, wantKey := range tt.wantKeys {
+				param := params[region]["/app/a"]
+				if param.Type != tt.wantType || param.KeyID != wantKey {
+					t.Errorf("parameter in region '%s' = %s with key %q, want %s with key %q",
+						region, param.Type, param.KeyID, tt.wantType, wantKey)
+				}
+				if param.Version != 2 {
+					t.Errorf("parameter in region '%s' has version %d, want 2", region, param.Version)
+				}
+			}
+		})
+	}
+}
+
+func TestModifyStringListItems(t *testing.T) {
+	ts := setupTest(t)
+	t.Cleanup(ts.cleanup)
+
+	tests := []struct {
+		name     string
+		current  string
+		args     []string
+		wantErr  bool
+		wantPuts int
+	}{
+		{name: "convert_with_empty_item", current: aws.ParameterTypeString, args: []string{"--type", "StringList", "--value", "a,,b"}, wantErr: true},
+		{name: "existing_with_trailing_comma", current: aws.ParameterTypeStringList, args: []string{"--value", "a,"}, wantErr: true},
+		{name: "existing_valid", current: aws.ParameterTypeStringList, args: []string{"--value", "a,b"}, wantPuts: 1},
+		{name: "string_with_empty_item", current: aws.ParameterTypeString, args: []string{"--value", "a,,b"}, wantPuts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newRegionStore(map[string]map[string]aws.Parameter{
+				"us-west-2": {"/app/a": {Name: "/app/a", Value: "old", Type: tt.current, Version: 1}},
+			})
+			store.install()
+
+			setupModifyFlags()
+			testRoot.AddCommand(modifyCmd)
+			testRoot.SetArgs(append([]string{"modify", "--path", "/app/a", "--region", "us-west-2"}, tt.args...))
+			err := testRoot.Execute()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runModify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(store.puts["us-west-2"]); got != tt.wantPuts {
+				t.Errorf("runModify() wrote %d time(s), want %d", got, tt.wantPuts)
+			}
+		})
+	}
+}
+
+func TestModifySkipsUnchanged(t *testing.T) {
+	ts := setupTest(t)
+	t.Cleanup(ts.cleanup)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantPuts map[string]int
+	}{
+		{
+			name:     "unchanged",
+			args:     []string{"--value", "same"},
+			wantPuts: map[string]int{"us-west-2": 0, "eu-west-1": 0},
+		},
+		{
+			name:     "changed_in_replica_only",
+			args:     []string{"--value", "same", "--description", "desc"},
+			wantPuts: map[string]int{"us-west-2": 0, "eu-west-1": 1},
+		},
+		{
+			name:     "forced",
+			args:     []string{"--value", "same", "--force"},
+			wantPuts: map[string]int{"us-west-2": 1, "eu-west-1": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newRegionStore(map[string]map[string]aws.Parameter{
+				"us-west-2": {"/app/a": {Name: "/app/a", Value: "same", Type: aws.ParameterTypeString, Description: "desc"}},
+				"eu-west-1": {"/app/a": {Name: "/app/a", Value: "same", Type: aws.ParameterTypeString}},
+			})
+			store.install()
+
+			setupModifyFlags()
+			testRoot.AddCommand(modifyCmd)
+			args := []string{"modify", "--path", "/app/a", "--region", "us-west-2", "--replica", "eu-west-1"}
+			testRoot.SetArgs(append(args, tt.args...))
+			if err := testRoot.Execute(); err != nil {
+				t.Fatalf("runModify() error = %v", err)
+			}
+
+			for region, want := range tt.wantPuts {
+				if got := len(store.puts[region]); got != want {
+					t.Errorf("runModify() wrote %d time(s) in region '%s', want %d", got, region, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"git.sr.ht/~wombelix/params2env/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// k8sMetadata is the metadata of a Kubernetes object
+type k8sMetadata struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// k8sObject is a Kubernetes Secret or ConfigMap
+type k8sObject struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sMetadata       `yaml:"metadata"`
+	Type       string            `yaml:"type,omitempty"`
+	Data       map[string]string `yaml:"data"`
+}
+
+// standaloneFormats are the formats that can't be combined with other
+// formats in one output
+var standaloneFormats = []string{config.OutputK8s, config.OutputGitHub}
+
+// varFormats returns the formats of the variables in order of first use.
+// Variables without a format use the given one.
+func varFormats(format string, vars []envVar) []string {
+	formats := []string{}
+	for _, v := range vars {
+		f := v.format
+		if f == "" {
+			f = format
+		}
+		if !slices.Contains(formats, f) {
+			formats = append(formats, f)
+		}
+	}
+	if len(formats) == 0 {
+		formats = append(formats, format)
+	}
+	return formats
+}
+
+// renderGroups renders the variables grouped by their format, the groups
+// are concatenated in order of first use. Variables without a format use
+// the given one.
+//
+// Parameters:
+//   - format: The format of variables without one
+//   - vars: The variables to render
+//
+// Returns:
+//   - The rendered output
+//   - An error if a value can't be represented in its format, or a format
+//     that can't be combined is used with others
+func renderGroups(format string, vars []envVar) (string, error) {
+	formats := varFormats(format, vars)
+	if len(formats) == 1 {
+		return renderVars(formats[0], vars)
+	}
+
+	var b strings.Builder
+	for _, f := range formats {
+		if slices.Contains(standaloneFormats, f) {
+			return "", fmt.Errorf("the %s format can't be combined with other formats (%s)", f, strings.Join(formats, ", "))
+		}
+		var group []envVar
+		for _, v := range vars {
+			if v.format == f || v.format == "" && f == format {
+				group = append(group, v)
+			}
+		}
+		output, err := renderVars(f, group)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(output)
+	}
+	return b.String(), nil
+}
+
+// renderVars renders environment variables in an output format
+//
+// Parameters:
+//   - format: One of config.OutputFormats
+//   - vars: The variables to render
+//
+// Returns:
+//   - The rendered output
+//   - An error if a value can't be represented in the format
+func renderVars(format string, vars []envVar) (string, error) {
+	switch format {
+	case config.OutputShell:
+		return formatExports(vars), nil
+	case config.OutputDocker:
+		return formatDockerEnv(vars)
+	case config.OutputSystemd:
+		return formatSystemdEnv(vars), nil
+	case config.OutputK8s:
+		return formatK8sManifest(vars, readK8sName, readK8sNamespace)
+	case config.OutputGitHub:
+		return formatGitHubEnv(vars)
+	case config.OutputGitLabDotenv:
+		return formatGitLabDotenv(vars)
+	}
+	return "", fmt.Errorf("invalid output format: %s (must be one of %s)", format, strings.Join(config.OutputFormats, ", "))
+}
+
+// formatDockerEnv renders variables as a Docker env file. Docker takes
+// everything after the first = literally and has no quoting, so multiline
+// values can't be represented.
+func formatDockerEnv(vars []envVar) (string, error) {
+	var b strings.Builder
+	for _, v := range vars {
+		if strings.ContainsAny(v.value, "\r\n") {
+			return "", fmt.Errorf("value of %s contains a line break, which the docker format doesn't support", v.name)
+		}
+		fmt.Fprintf(&b, "%s=%s\n", v.name, v.value)
+	}
+	return b.String(), nil
+}
+
+// systemdEscaper escapes the characters systemd interprets in double quoted
+// EnvironmentFile values
+var systemdEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", `$`, `\$`)
+
+// formatSystemdEnv renders variables as a systemd EnvironmentFile. Values
+// are double quoted, line breaks are kept inside the quotes.
+func formatSystemdEnv(vars []envVar) string {
+	var b strings.Builder
+	for _, v := range vars {
+		fmt.Fprintf(&b, "%s=\"%s\"\n", v.name, systemdEscaper.Replace(v.value))
+	}
+	return b.String()
+}
+
+// formatK8sManifest renders variables as Kubernetes manifest. SecureString
+// values are stored base64 encoded in a Secret, all other values as plain
+// text in a ConfigMap. Objects without values are left out.
+func formatK8sManifest(vars []envVar, name, namespace string) (string, error) {
+	secret := k8sObject{APIVersion: "v1", Kind: "Secret", Type: "Opaque", Data: map[string]string{}}
+	configMap := k8sObject{APIVersion: "v1", Kind: "ConfigMap", Data: map[string]string{}}
+	for _, v := range vars {
+		if v.secure {
+			secret.Data[v.name] = base64.StdEncoding.EncodeToString([]byte(v.value))
+		} else {
+			configMap.Data[v.name] = v.value
+		}
+	}
+
+	var docs []string
+	for _, obj := range []k8sObject{secret, configMap} {
+		if len(obj.Data) == 0 {
+			continue
+		}
+		obj.Metadata = k8sMetadata{Name: name, Namespace: namespace}
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", fmt.Errorf("failed to render %s: %w", obj.Kind, err)
+		}
+		docs = append(docs, string(out))
+	}
+	return strings.Join(docs, "---\n"), nil
+}
+
+// formatGitHubEnv renders variables for the GitHub Actions environment file.
+// Multiline values use the heredoc syntax with a random delimiter that
+// doesn't occur in the value.
+func formatGitHubEnv(vars []envVar) (string, error) {
+	var b strings.Builder
+	for _, v := range vars {
+		if !strings.ContainsAny(v.value, "\r\n") {
+			fmt.Fprintf(&b, "%s=%s\n", v.name, v.value)
+			continue
+		}
+		delimiter, err := heredocDelimiter(v.value)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "%s<<%s\n%s\n%s\n", v.name, delimiter, v.value, delimiter)
+	}
+	return b.String(), nil
+}
+
+// heredocDelimiter returns a random heredoc delimiter not contained in value
+func heredocDelimiter(value string) (string, error) {
+	for {
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err != nil {
+			return "", fmt.Errorf("failed to read random data: %w", err)
+		}
+		delimiter := "ghadelimiter_" + hex.EncodeToString(buf)
+		if !strings.Contains(value, delimiter) {
+			return delimiter, nil
+		}
+	}
+}
+
+// githubMaskEscaper escapes the data of a GitHub Actions workflow command
+var githubMaskEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+
+// formatGitHubMasks returns ::add-mask:: workflow commands for all
+// SecureString values. GitHub masks line by line, so every line of a
+// multiline value is masked on its own.
+func formatGitHubMasks(vars []envVar) string {
+	var b strings.Builder
+	for _, v := range vars {
+		if !v.secure {
+			continue
+		}
+		for _, line := range strings.FieldsFunc(v.value, func(r rune) bool { return r == '\n' || r == '\r' }) {
+			fmt.Fprintf(&b, "::add-mask::%s\n", githubMaskEscaper.Replace(line))
+		}
+	}
+	return b.String()
+}
+
+// gitlabKeyPattern matches the variable names GitLab accepts in dotenv
+// reports
+var gitlabKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// formatGitLabDotenv renders variables as GitLab CI dotenv report. GitLab
+// doesn't support multiline values or quoting in dotenv reports.
+func formatGitLabDotenv(vars []envVar) (string, error) {
+	var b strings.Builder
+	for _, v := range vars {
+		if !gitlabKeyPattern.MatchString(v.name) {
+			return "", fmt.Errorf("variable name %s isn't supported by the gitlab-dotenv format", v.name)
+		}
+		if strings.ContainsAny(v.value, "\r\n") {
+			return "", fmt.Errorf("value of %s contains a line break, which the gitlab-dotenv format doesn't support", v.name)
+		}
+		fmt.Fprintf(&b, "%s=%s\n", v.name, v.value)
+	}
+	return b.String(), nil
+}
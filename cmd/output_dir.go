@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"git.sr.ht/~wombelix/params2env/internal/config"
+	"git.sr.ht/~wombelix/params2env/internal/fileutil"
+)
+
+// Naming rules for the files written to the output directory
+const (
+	// fileNamingEnv names the files like the environment variables
+	fileNamingEnv = "env"
+	// fileNamingPath names the files by the last segment of the parameter path
+	fileNamingPath = "path"
+)
+
+// outputDirManifest lists the files a previous run wrote to the output
+// directory, so files of parameters removed from the config can be cleaned
+// up without touching anything else in the directory
+const outputDirManifest = ".params2env-files"
+
+// outputName returns the name of the variable or file a parameter is
+// written to
+func outputName(paramPath, envName string, cfg *config.Config) string {
+	if readOutputDir != "" && readFileNaming == fileNamingPath {
+		return path.Base(paramPath)
+	}
+	return formatEnvName(paramPath, envName, cfg)
+}
+
+// validateOutputFileName checks if a name can be used as file name in the
+// output directory. Hidden files are reserved for the manifest.
+func validateOutputFileName(name string) error {
+	if name == "" || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid output file name: '%s'", name)
+	}
+	return nil
+}
+
+// writeOutputDir writes the value of every variable to its own file in dir.
+// Unchanged files are left alone, changed files are replaced atomically and
+// files written by a previous run for variables that are gone are removed.
+//
+// Parameters:
+//   - dir: The output directory
+//   - vars: The variables to write, the name is used as file name
+//   - params: The parameters the variables were read from
+//   - settings: The permissions and ownership of the files
+//
+// Returns:
+//   - Whether a file was written or removed
+//   - An error if a file can't be written or removed
+func writeOutputDir(dir string, vars []envVar, params []config.ParamConfig, settings outputFileSettings) (bool, error) {
+	names := make([]string, 0, len(vars))
+	for _, v := range vars {
+		if err := validateOutputFileName(v.name); err != nil {
+			return false, err
+		}
+		if slices.Contains(names, v.name) {
+			return false, fmt.Errorf("multiple parameters are written to the same file '%s'", v.name)
+		}
+		names = append(names, v.name)
+	}
+
+	// Ensure directory exists with secure permissions (0700 - owner access only)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return false, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	for _, param := range params {
+		fmt.Printf("Reading parameter '%s' from region '%s'\n", param.Name, readRegion)
+	}
+
+	// Concurrent runs writing the same directory wait for each other
+	unlock, err := lockOutput(dir)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = unlock() }()
+
+	var changed bool
+	previous, err := readOutputDirManifest(dir)
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range vars {
+		file := filepath.Join(dir, v.name)
+		unchanged, err := fileUnchanged(file, []byte(v.value), settings)
+		if err != nil {
+			return false, err
+		}
+		if unchanged {
+			continue
+		}
+		if err := writeOutputFile(file, []byte(v.value), settings); err != nil {
+			return false, err
+		}
+		fmt.Printf("Parameter value written to %s\n", file)
+		changed = true
+	}
+
+	for _, name := range previous {
+		if slices.Contains(names, name) || validateOutputFileName(name) != nil {
+			continue
+		}
+		file := filepath.Join(dir, name)
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return false, fmt.Errorf("failed to remove %s: %w", file, err)
+		}
+		fmt.Printf("Removed %s\n", file)
+		changed = true
+	}
+
+	slices.Sort(names)
+	manifest := strings.Join(names, "\n") + "\n"
+	if err := fileutil.WriteAtomic(filepath.Join(dir, outputDirManifest), []byte(manifest), 0600); err != nil {
+		return false, err
+	}
+	return changed, nil
+}
+
+// readOutputDirManifest returns the files listed in the manifest of the
+// output directory, or nothing if there is no manifest yet
+func readOutputDirManifest(dir string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, outputDirManifest))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest of %s: %w", dir, err)
+	}
+	return strings.Fields(string(data)), nil
+}
+
+// fileUnchanged checks if a file exists with the given content, mode and
+// ownership. The owner and group are only compared if they are set.
+func fileUnchanged(file string, data []byte, settings outputFileSettings) (bool, error) {
+	info, err := os.Stat(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	if info.Mode().Perm() != settings.mode {
+		return false, nil
+	}
+	if settings.uid != -1 || settings.gid != -1 {
+		uid, gid, ok := fileOwner(info)
+		if ok && (settings.uid != -1 && uid != settings.uid || settings.gid != -1 && gid != settings.gid) {
+			return false, nil
+		}
+	}
+	current, err := os.ReadFile(file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return bytes.Equal(current, data), nil
+}
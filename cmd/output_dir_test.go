@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/spf13/cobra"
+)
+
+// runReadOutputDir runs the read command with the given config and arguments
+func runReadOutputDir(t *testing.T, configFile, config string, args ...string) error {
+	t.Helper()
+	if err := os.WriteFile(configFile, []byte(config), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	testRoot := &cobra.Command{Use: "params2env"}
+	setupReadFlags(t, testRoot)
+	// The path is optional with parameters in the config file
+	readCmd.Flags().Lookup("path").Annotations = nil
+
+	oldStdout := os.Stdout
+	devNull, _ := os.Open(os.DevNull)
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = oldStdout
+		_ = devNull.Close()
+	}()
+
+	testRoot.SetArgs(append([]string{"read"}, args...))
+	return testRoot.Execute()
+}
+
+func TestRunReadOutputDir(t *testing.T) {
+	rts := setupReadTest(t)
+	defer rts.cleanup()
+
+	aws.NewClient = func(ctx context.Context, region, role string) (*aws.Client, error) {
+		return &aws.Client{SSMClient: &aws.MockSSMClient{
+			GetParamFunc: func(ctx context.Context, input *ssm.GetParameterInput, opts ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+				value := "value of " + *input.Name
+				return &ssm.GetParameterOutput{
+					Parameter: &types.Parameter{Value: &value, Type: types.ParameterTypeString},
+				}, nil
+			},
+		}}, nil
+	}
+
+	configFile := filepath.Join(rts.tmpDir, ".params2env.yaml")
+	dir := filepath.Join(rts.tmpDir, "creds")
+	config := "params:\n  - name: /app/db_password\n  - name: /app/url\n    env: APP_URL\n"
+
+	if err := runReadOutputDir(t, configFile, config, "--output-dir", dir); err != nil {
+		t.Fatalf("runRead() error = %v", err)
+	}
+	for name, want := range map[string]string{"DB_PASSWORD": "value of /app/db_password", "APP_URL": "value of /app/url"} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if string(content) != want {
+			t.Errorf("%s = %q, want %q", name, content, want)
+		}
+		if info, _ := os.Stat(filepath.Join(dir, name)); info.Mode().Perm() != 0600 {
+			t.Errorf("%s mode = %v, want 0600", name, info.Mode().Perm())
+		}
+	}
+	if info, _ := os.Stat(dir); info.Mode().Perm() != 0700 {
+		t.Errorf("directory mode = %v, want 0700", info.Mode().Perm())
+	}
+
+	// Files of removed parameters are deleted, other files are kept
+	if err := os.WriteFile(filepath.Join(dir, "OTHER"), []byte("x"), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := runReadOutputDir(t, configFile, "params:\n  - name: /app/db_password\n", "--output-dir", dir); err != nil {
+		t.Fatalf("runRead() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "APP_URL")); !os.IsNotExist(err) {
+		t.Errorf("APP_URL still exists, want it removed")
+	}
+	for _, name := range []string{"DB_PASSWORD", "OTHER"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s missing, want it kept: %v", name, err)
+		}
+	}
+
+	// Files are named by the path segment and the directory from the config
+	pathDir := filepath.Join(rts.tmpDir, "path")
+	if err := runReadOutputDir(t, configFile, "output_dir: "+pathDir+"\nparams:\n  - name: /app/url\n    env: APP_URL\n", "--file-naming", "path"); err != nil {
+		t.Fatalf("runRead() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(pathDir, "url")); err != nil {
+		t.Errorf("url missing: %v", err)
+	}
+}
+
+func TestRunReadOutputDirInvalid(t *testing.T) {
+	rts := setupReadTest(t)
+	defer rts.cleanup()
+
+	configFile := filepath.Join(rts.tmpDir, ".params2env.yaml")
+	dir := filepath.Join(rts.tmpDir, "creds")
+
+	tests := []struct {
+		name   string
+		config string
+		args   []string
+		errMsg string
+	}{
+		{
+			name:   "with_file",
+			config: "params:\n  - name: /app/url\n",
+			args:   []string{"--output-dir", dir, "--file", "app.env"},
+			errMsg: "mutually exclusive",
+		},
+		{
+			name:   "with_format",
+			config: "params:\n  - name: /app/url\n",
+			args:   []string{"--output-dir", dir, "--format", "docker"},
+			errMsg: "\"format\" can't be used",
+		},
+		{
+			name:   "invalid_naming",
+			config: "params:\n  - name: /app/url\n",
+			args:   []string{"--output-dir", dir, "--file-naming", "hash"},
+			errMsg: "invalid file naming",
+		},
+		{
+			name:   "duplicate_file",
+			config: "params:\n  - name: /a/url\n  - name: /b/url\n",
+			args:   []string{"--output-dir", dir},
+			errMsg: "both map to the name 'URL'",
+		},
+		{
+			name:   "hidden_file",
+			config: "params:\n  - name: /app/url\n    env: .hidden\n",
+			args:   []string{"--output-dir", dir, "--upper=false"},
+			errMsg: "invalid output file name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runReadOutputDir(t, configFile, tt.config, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("runRead() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
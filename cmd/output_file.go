@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+
+	"git.sr.ht/~wombelix/params2env/internal/fileutil"
+)
+
+// outputFileSettings are the permissions and ownership of written files
+type outputFileSettings struct {
+	mode os.FileMode
+	// uid and gid are -1 to keep the owner and group of the process
+	uid int
+	gid int
+}
+
+// parseOutputFileSettings parses the file mode, owner and group flags of the
+// read command
+//
+// Parameters:
+//   - mode: Octal file mode, e.g. 0640
+//   - owner: User name or numeric user ID, empty to keep the owner
+//   - group: Group name or numeric group ID, empty to keep the group
+//
+// Returns:
+//   - The parsed settings
+//   - An error if the mode is invalid or the user or group doesn't exist
+func parseOutputFileSettings(mode, owner, group string) (outputFileSettings, error) {
+	settings := outputFileSettings{uid: -1, gid: -1}
+
+	perm, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || perm > 0777 {
+		return settings, fmt.Errorf("invalid file mode: %s (must be an octal permission like 0600)", mode)
+	}
+	settings.mode = os.FileMode(perm)
+
+	if owner != "" {
+		if settings.uid, err = strconv.Atoi(owner); err != nil {
+			u, err := user.Lookup(owner)
+			if err != nil {
+				return settings, fmt.Errorf("invalid file owner: %w", err)
+			}
+			if settings.uid, err = strconv.Atoi(u.Uid); err != nil {
+				return settings, fmt.Errorf("invalid file owner: %s has no numeric user ID", owner)
+			}
+		}
+	}
+
+	if group != "" {
+		if settings.gid, err = strconv.Atoi(group); err != nil {
+			g, err := user.LookupGroup(group)
+			if err != nil {
+				return settings, fmt.Errorf("invalid file group: %w", err)
+			}
+			if settings.gid, err = strconv.Atoi(g.Gid); err != nil {
+				return settings, fmt.Errorf("invalid file group: %s has no numeric group ID", group)
+			}
+		}
+	}
+
+	return settings, nil
+}
+
+// lockOutput locks the output file or directory against concurrent runs.
+// The lock files are kept in a private directory of the current user, named
+// by the hash of the absolute output path, so no files are left next to the
+// output. Runs of other users don't wait for each other.
+func lockOutput(target string) (func() error, error) {
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", target, err)
+	}
+	dir, err := lockDir()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return fileutil.Lock(filepath.Join(dir, hex.EncodeToString(sum[:16])+".lock"))
+}
+
+// lockDir returns the directory of the lock files in the temporary
+// directory, creating it if needed. The directory must only be accessible
+// by the current user, so nobody else can replace the lock files.
+func lockDir() (string, error) {
+	name := "params2env-locks"
+	if uid := os.Getuid(); uid >= 0 {
+		name = fmt.Sprintf("params2env-%d-locks", uid)
+	}
+	dir := filepath.Join(os.TempDir(), name)
+	if err := os.Mkdir(dir, 0700); err != nil && !errors.Is(err, os.ErrExist) {
+		return "", fmt.Errorf("failed to create lock directory: %w", err)
+	}
+
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read lock directory: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("lock directory %s is not a directory", dir)
+	}
+	if uid, _, ok := fileOwner(info); ok && (uid != os.Getuid() || info.Mode().Perm() != 0700) {
+		return "", fmt.Errorf("lock directory %s must be owned by the current user with mode 0700", dir)
+	}
+	return dir, nil
+}
+
+// existingFileSettings returns the settings with the mode, owner and group
+// of an existing file for those that aren't set explicitly, so a file that
+// is updated in place keeps them. Unset owner and group are -1.
+func existingFileSettings(file string, settings outputFileSettings, modeSet bool) (outputFileSettings, error) {
+	info, err := os.Stat(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	if !modeSet {
+		settings.mode = info.Mode().Perm()
+	}
+	if uid, gid, ok := fileOwner(info); ok {
+		if settings.uid == -1 {
+			settings.uid = uid
+		}
+		if settings.gid == -1 {
+			settings.gid = gid
+		}
+	}
+	return settings, nil
+}
+
+// writeOutputFile atomically replaces a file with the configured
+// permissions and ownership
+func writeOutputFile(file string, data []byte, settings outputFileSettings) error {
+	return fileutil.WriteAtomicOwned(file, data, settings.mode, settings.uid, settings.gid)
+}
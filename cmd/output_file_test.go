@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestParseOutputFileSettings(t *testing.T) {
+	current, err := user.Current()
+	if err != nil {
+		t.Skipf("Failed to look up current user: %v", err)
+	}
+	uid, _ := strconv.Atoi(current.Uid)
+
+	tests := []struct {
+		name    string
+		mode    string
+		owner   string
+		group   string
+		want    outputFileSettings
+		wantErr string
+	}{
+		{name: "default", mode: "0600", want: outputFileSettings{mode: 0600, uid: -1, gid: -1}},
+		{name: "without_leading_zero", mode: "640", want: outputFileSettings{mode: 0640, uid: -1, gid: -1}},
+		{name: "numeric_ids", mode: "0600", owner: "1000", group: "1000", want: outputFileSettings{mode: 0600, uid: 1000, gid: 1000}},
+		{name: "user_name", mode: "0600", owner: current.Username, want: outputFileSettings{mode: 0600, uid: uid, gid: -1}},
+		{name: "invalid_mode", mode: "0800", wantErr: "invalid file mode"},
+		{name: "mode_too_large", mode: "1777", wantErr: "invalid file mode"},
+		{name: "unknown_owner", mode: "0600", owner: "params2env-no-such-user", wantErr: "invalid file owner"},
+		{name: "unknown_group", mode: "0600", group: "params2env-no-such-group", wantErr: "invalid file group"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOutputFileSettings(tt.mode, tt.owner, tt.group)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseOutputFileSettings() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseOutputFileSettings() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseOutputFileSettings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunReadFileMode(t *testing.T) {
+	rts := setupReadTest(t)
+	defer rts.cleanup()
+
+	file := filepath.Join(rts.tmpDir, "env", "app.env")
+	testRoot := &cobra.Command{Use: "params2env"}
+	setupReadFlags(t, testRoot)
+
+	oldStdout := os.Stdout
+	devNull, _ := os.Open(os.DevNull)
+	os.Stdout = devNull
+	testRoot.SetArgs([]string{"read", "--path", "/app/url", "--file", file, "--file-mode", "0640", "--file-group", strconv.Itoa(os.Getgid())})
+	err := testRoot.Execute()
+	os.Stdout = oldStdout
+	_ = devNull.Close()
+	if err != nil {
+		t.Fatalf("runRead() error = %v", err)
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatalf("Failed to stat file: %v", err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("mode = %v, want 0640", info.Mode().Perm())
+	}
+	content, _ := os.ReadFile(file)
+	if string(content) != "export URL=\"test-value\"\n" {
+		t.Errorf("content = %q", content)
+	}
+	entries, err := os.ReadDir(filepath.Dir(file))
+	if err != nil {
+		t.Fatalf("Failed to read directory: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory contains %d entries, want only the output file", len(entries))
+	}
+}
+
+func TestLockDir(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	dir, err := lockDir()
+	if err != nil {
+		t.Fatalf("lockDir() error = %v", err)
+	}
+	if filepath.Dir(dir) != tmp {
+		t.Errorf("lockDir() = %s, want a directory in %s", dir, tmp)
+	}
+
+	unlock, err := lockOutput(filepath.Join(tmp, "app.env"))
+	if err != nil {
+		t.Fatalf("lockOutput() error = %v", err)
+	}
+	if err := unlock(); err != nil {
+		t.Errorf("unlock() error = %v", err)
+	}
+
+	if err := os.Chmod(dir, 0755); err != nil {
+		t.Fatalf("Failed to change mode: %v", err)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		t.Fatalf("Failed to stat lock directory: %v", err)
+	}
+	if _, _, ok := fileOwner(info); !ok {
+		t.Skip("File ownership not supported")
+	}
+	if _, err := lockDir(); err == nil {
+		t.Error("lockDir() expected error for a directory other users can access")
+	}
+}
+
+func TestFileUnchangedOwnership(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "app.env")
+	if err := os.WriteFile(file, []byte("x"), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatalf("Failed to stat file: %v", err)
+	}
+	uid, gid, ok := fileOwner(info)
+	if !ok {
+		t.Skip("File ownership not supported")
+	}
+
+	tests := []struct {
+		name     string
+		settings outputFileSettings
+		want     bool
+	}{
+		{name: "ownership_not_set", settings: outputFileSettings{mode: 0600, uid: -1, gid: -1}, want: true},
+		{name: "same_ownership", settings: outputFileSettings{mode: 0600, uid: uid, gid: gid}, want: true},
+		{name: "other_owner", settings: outputFileSettings{mode: 0600, uid: uid + 1, gid: -1}, want: false},
+		{name: "other_group", settings: outputFileSettings{mode: 0600, uid: -1, gid: gid + 1}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fileUnchanged(file, []byte("x"), tt.settings)
+			if err != nil {
+				t.Fatalf("fileUnchanged() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("fileUnchanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"git.sr.ht/~wombelix/params2env/internal/config"
+)
+
+func TestRenderVars(t *testing.T) {
+	vars := []envVar{
+		{name: "URL", value: "https://example.com/?a=1&b=$x"},
+		{name: "PASSWORD", value: `p"w\d`, secure: true},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		vars   []envVar
+		want   string
+		errMsg string
+	}{
+		{
+			name:   "shell",
+			format: config.OutputShell,
+			vars:   vars,
+			want:   "export URL=\"https://example.com/?a=1&b=$x\"\nexport PASSWORD=\"p\\\"w\\\\d\"\n",
+		},
+		{
+			name:   "docker",
+			format: config.OutputDocker,
+			vars:   vars,
+			want:   "URL=https://example.com/?a=1&b=$x\nPASSWORD=p\"w\\d\n",
+		},
+		{
+			name:   "docker_multiline",
+			format: config.OutputDocker,
+			vars:   []envVar{{name: "CERT", value: "line1\nline2"}},
+			errMsg: "value of CERT contains a line break",
+		},
+		{
+			name:   "systemd",
+			format: config.OutputSystemd,
+			vars:   append(vars, envVar{name: "CERT", value: "line1\nline2"}),
+			want:   "URL=\"https://example.com/?a=1&b=\\$x\"\nPASSWORD=\"p\\\"w\\\\d\"\nCERT=\"line1\nline2\"\n",
+		},
+		{
+			name:   "k8s",
+			format: config.OutputK8s,
+			vars:   vars,
+			want: `apiVersion: v1
+kind: Secret
+metadata:
+    name: myapp
+    namespace: prod
+type: Opaque
+data:
+    PASSWORD: cCJ3XGQ=
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+    name: myapp
+    namespace: prod
+data:
+    URL: https://example.com/?a=1&b=$x
+`,
+		},
+		{
+			name:   "k8s_secret_only",
+			format: config.OutputK8s,
+			vars:   vars[1:],
+			want:   "apiVersion: v1\nkind: Secret\nmetadata:\n    name: myapp\n    namespace: prod\ntype: Opaque\ndata:\n    PASSWORD: cCJ3XGQ=\n",
+		},
+		{
+			name:   "github",
+			format: config.OutputGitHub,
+			vars:   vars,
+			want:   "URL=https://example.com/?a=1&b=$x\nPASSWORD=p\"w\\d\n",
+		},
+		{
+			name:   "gitlab_dotenv",
+			format: config.OutputGitLabDotenv,
+			vars:   vars,
+			want:   "URL=https://example.com/?a=1&b=$x\nPASSWORD=p\"w\\d\n",
+		},
+		{
+			name:   "gitlab_dotenv_multiline",
+			format: config.OutputGitLabDotenv,
+			vars:   []envVar{{name: "CERT", value: "line1\nline2"}},
+			errMsg: "value of CERT contains a line break",
+		},
+		{
+			name:   "gitlab_dotenv_invalid_name",
+			format: config.OutputGitLabDotenv,
+			vars:   []envVar{{name: "MY-URL", value: "x"}},
+			errMsg: "variable name MY-URL isn't supported",
+		},
+		{
+			name:   "invalid",
+			format: "xml",
+			vars:   vars,
+			errMsg: "invalid output format",
+		},
+	}
+
+	readK8sName, readK8sNamespace = "myapp", "prod"
+	defer func() { readK8sName, readK8sNamespace = "params2env", "" }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderVars(tt.format, tt.vars)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("renderVars() error = %v, want error containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("renderVars() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("renderVars() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatGitHubEnvMultiline(t *testing.T) {
+	got, err := formatGitHubEnv([]envVar{{name: "CERT", value: "line1\nline2"}})
+	if err != nil {
+		t.Fatalf("formatGitHubEnv() error = %v", err)
+	}
+
+	lines := strings.Split(got, "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[0], "CERT<<ghadelimiter_") {
+		t.Fatalf("formatGitHubEnv() = %q, want heredoc syntax", got)
+	}
+	delimiter := strings.TrimPrefix(lines[0], "CERT<<")
+	if lines[1] != "line1" || lines[2] != "line2" || lines[3] != delimiter || lines[4] != "" {
+		t.Errorf("formatGitHubEnv() = %q, want value enclosed by %s", got, delimiter)
+	}
+}
+
+func TestFormatGitHubMasks(t *testing.T) {
+	vars := []envVar{
+		{name: "URL", value: "https://example.com"},
+		{name: "PASSWORD", value: "100%", secure: true},
+		{name: "KEY", value: "line1\r\nline2\n", secure: true},
+	}
+
+	want := "::add-mask::100%25\n::add-mask::line1\n::add-mask::line2\n"
+	if got := formatGitHubMasks(vars); got != want {
+		t.Errorf("formatGitHubMasks() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package cmd
+
+import "os"
+
+// fileOwner fails on Windows, files have no numeric owner there
+func fileOwner(info os.FileInfo) (uid, gid int, ok bool) {
+	return 0, 0, false
+}
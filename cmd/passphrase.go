@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+// passphraseEnv is the environment variable holding the backup passphrase
+const passphraseEnv = "PARAMS2ENV_PASSPHRASE"
+
+// readPassphrase returns the passphrase of an encrypted backup from the
+// PARAMS2ENV_PASSPHRASE environment variable or prompts for it on the
+// terminal. With confirm set, a prompted passphrase has to be entered twice.
+func readPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(passphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	if !isTerminal() {
+		return "", fmt.Errorf("passphrase must be set via %s environment variable or entered on a terminal", passphraseEnv)
+	}
+	return promptSecret("Passphrase", "passphrase", confirm)
+}
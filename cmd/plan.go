@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+)
+
+// Actions of a dry-run plan
+const (
+	planActionCreate = "create"
+	planActionUpdate = "update"
+	planActionNoop   = "no-op"
+	planActionDelete = "delete"
+	planActionFail   = "fail"
+)
+
+// regionPlan is the planned change of a parameter in a single region
+type regionPlan struct {
+	// action is one of the planAction constants
+	action string
+	// details describes the change, values are never included
+	details []string
+}
+
+// runPlan computes the plan for every region in parallel, prints it in the
+// order of regions and returns an error if the change would fail in any
+// region. Nothing is written.
+func runPlan(path string, regions []string, plan func(region string) (regionPlan, error)) error {
+	var mu sync.Mutex
+	plans := make(map[string]regionPlan, len(regions))
+	results := runInRegions(regions, func(region string) error {
+		p, err := plan(region)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		plans[region] = p
+		mu.Unlock()
+		return nil
+	})
+
+	fmt.Printf("Dry run, no changes are made to parameter '%s':\n", path)
+	var errs []error
+	for _, result := range results {
+		p := plans[result.region]
+		if result.err != nil {
+			p = regionPlan{action: planActionFail, details: []string{result.err.Error()}}
+		}
+		if p.action == planActionFail {
+			errs = append(errs, fmt.Errorf("region '%s': %s", result.region, strings.Join(p.details, ", ")))
+		}
+
+		line := fmt.Sprintf("  %s: %s", result.region, p.action)
+		if len(p.details) > 0 {
+			line += " (" + strings.Join(p.details, ", ") + ")"
+		}
+		fmt.Println(line)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("change of parameter '%s' would fail in %d of %d region(s): %w",
+			path, len(errs), len(regions), errors.Join(errs...))
+	}
+	return nil
+}
+
+// currentParameter reads the current state of a parameter in a region,
+// nil if it doesn't exist
+func currentParameter(region, role, path string) (*aws.Parameter, error) {
+	ctx := context.Background()
+	client, err := aws.NewClient(ctx, region, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	param, err := client.DescribeParameter(ctx, path)
+	if err != nil {
+		if errors.Is(err, aws.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return param, nil
+}
+
+// isUnchanged reports whether the parameter in a region already has the
+// desired state, in which case a write can be skipped
+func isUnchanged(region, role string, desired aws.Parameter) (bool, error) {
+	current, err := currentParameter(region, role, desired.Name)
+	if err != nil {
+		return false, fmt.Errorf("failed to read current state (use --force to skip the check): %w", err)
+	}
+	return current != nil && len(parameterChanges(*current, desired)) == 0, nil
+}
+
+// planWrite returns the plan to write the desired parameter over the current
+// one. Empty fields of desired are not changed. An existing parameter is only
+// updated if overwrite is set.
+func planWrite(current *aws.Parameter, desired aws.Parameter, overwrite bool) regionPlan {
+	if current == nil {
+		details := []string{"type " + desired.Type}
+		if desired.Type == aws.ParameterTypeSecureString && desired.KeyID != "" {
+			details = append(details, "kms "+desired.KeyID)
+		}
+		if desired.Description != "" {
+			details = append(details, fmt.Sprintf("description %q", desired.Description))
+		}
+		details = append(details, "value set")
+		return regionPlan{action: planActionCreate, details: details}
+	}
+
+	if !overwrite {
+		return regionPlan{action: planActionFail, details: []string{"parameter already exists"}}
+	}
+
+	changes := parameterChanges(*current, desired)
+	if len(changes) == 0 {
+		return regionPlan{action: planActionNoop}
+	}
+	return regionPlan{action: planActionUpdate, details: changes}
+}
+
+// planDelete returns the plan to delete the current parameter
+func planDelete(current *aws.Parameter) regionPlan {
+	if current == nil {
+		return regionPlan{action: planActionFail, details: []string{"parameter not found"}}
+	}
+	return regionPlan{action: planActionDelete, details: []string{"type " + current.Type}}
+}
+
+// parameterChanges returns the differences between the current and the
+// desired state of a parameter. Empty fields of desired are not compared,
+// values are compared by hash and never included.
+func parameterChanges(current, desired aws.Parameter) []string {
+	var changes []string
+	if hashValue(current.Value) != hashValue(desired.Value) {
+		changes = append(changes, "value changed")
+	}
+	if desired.Type != "" && desired.Type != current.Type {
+		changes = append(changes, fmt.Sprintf("type %s -> %s", current.Type, desired.Type))
+	}
+	if desired.KeyID != "" && desired.KeyID != current.KeyID {
+		changes = append(changes, fmt.Sprintf("kms %s -> %s", orNone(current.KeyID), desired.KeyID))
+	}
+	if desired.Description != "" && desired.Description != current.Description {
+		changes = append(changes, fmt.Sprintf("description %q -> %q", current.Description, desired.Description))
+	}
+	return changes
+}
+
+// keepsDescription reports whether writing desired over current would keep a
+// description that should be cleared. SSM ignores an empty description when
+// a parameter is overwritten.
+func keepsDescription(current *aws.Parameter, desired aws.Parameter) bool {
+	return current != nil && current.Description != "" && desired.Description == ""
+}
+
+// orNone returns s or "none" if s is empty
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+)
+
+func TestPlanWrite(t *testing.T) {
+	current := &aws.Parameter{Name: "/app/a", Value: "secret", Type: aws.ParameterTypeString, Description: "desc"}
+
+	tests := []struct {
+		name        string
+		current     *aws.Parameter
+		desired     aws.Parameter
+		overwrite   bool
+		wantAction  string
+		wantDetails []string
+	}{
+		{
+			name:        "create",
+			desired:     aws.Parameter{Value: "secret", Type: aws.ParameterTypeSecureString, KeyID: "alias/app"},
+			wantAction:  planActionCreate,
+			wantDetails: []string{"type SecureString", "kms alias/app", "value set"},
+		},
+		{
+			name:        "exists_without_overwrite",
+			current:     current,
+			desired:     aws.Parameter{Value: "secret", Type: aws.ParameterTypeString},
+			wantAction:  planActionFail,
+			wantDetails: []string{"parameter already exists"},
+		},
+		{
+			name:       "unchanged",
+			current:    current,
+			desired:    aws.Parameter{Value: "secret", Type: aws.ParameterTypeString},
+			overwrite:  true,
+			wantAction: planActionNoop,
+		},
+		{
+			name:       "update",
+			current:    current,
+			desired:    aws.Parameter{Value: "other", Type: aws.ParameterTypeSecureString, KeyID: "alias/app", Description: "new"},
+			overwrite:  true,
+			wantAction: planActionUpdate,
+			wantDetails: []string{"value changed", "type String -> SecureString", "kms none -> alias/app",
+				`description "desc" -> "new"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := planWrite(tt.current, tt.desired, tt.overwrite)
+			if got.action != tt.wantAction || !reflect.DeepEqual(got.details, tt.wantDetails) {
+				t.Errorf("planWrite() = %s %v, want %s %v", got.action, got.details, tt.wantAction, tt.wantDetails)
+			}
+			for _, detail := range got.details {
+				if strings.Contains(detail, "secret") || strings.Contains(detail, "other") {
+					t.Errorf("planWrite() detail %q leaks the parameter value", detail)
+				}
+			}
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+	defer setupDryRunFlag()
+
+	existing := func() map[string]map[string]aws.Parameter {
+		return map[string]map[string]aws.Parameter{
+			"us-west-2": {"/app/a": {Name: "/app/a", Value: "old", Type: aws.ParameterTypeString}},
+			"eu-west-1": {},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		args    []string
+		wantErr bool
+	}{
+		{
+			name:  "create_new",
+			setup: setupCreateFlags,
+			args:  []string{"create", "--path", "/app/b", "--value", "new"},
+		},
+		{
+			name:    "create_existing",
+			setup:   setupCreateFlags,
+			args:    []string{"create", "--path", "/app/a", "--value", "new"},
+			wantErr: true,
+		},
+		{
+			name:  "create_overwrite",
+			setup: setupCreateFlags,
+			args:  []string{"create", "--path", "/app/a", "--value", "new", "--overwrite"},
+		},
+		{
+			name:    "modify_missing_in_replica",
+			setup:   setupModifyFlags,
+			args:    []string{"modify", "--path", "/app/a", "--value", "new"},
+			wantErr: true,
+		},
+		{
+			name:    "delete_missing_in_replica",
+			setup:   func() { setupDeleteFlags(t) },
+			args:    []string{"delete", "--path", "/app/a"},
+			wantErr: true,
+		},
+		{
+			name:  "delete_recursive",
+			setup: func() { setupDeleteFlags(t) },
+			args:  []string{"delete", "--prefix", "/app", "--recursive"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := existing()
+			store := newRegionStore(params)
+			store.failWrites = map[string]bool{"us-west-2": true, "eu-west-1": true}
+			store.install()
+
+			tt.setup()
+			setupDryRunFlag()
+			testRoot.AddCommand(createCmd, modifyCmd, deleteCmd)
+			testRoot.SetArgs(append(tt.args, "--region", "us-west-2", "--replica", "eu-west-1", "--dry-run"))
+			err := testRoot.Execute()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("dry run error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(params, existing()) {
+				t.Errorf("dry run changed parameters: %v", params)
+			}
+		})
+	}
+}
@@ -9,8 +9,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"path"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"syscall"
+	"time"
 
 	"git.sr.ht/~wombelix/params2env/internal/aws"
 	"git.sr.ht/~wombelix/params2env/internal/config"
@@ -34,8 +40,77 @@ var (
 	readPrefix string
 	// readEnvName overrides the default environment variable name
 	readEnvName string
+	// readEnvNaming derives the environment variable names from the
+	// parameter paths, one of config.EnvNamings
+	readEnvNaming string
+	// readStripPrefix is removed from the parameter paths by the relative
+	// naming strategy
+	readStripPrefix string
+	// readListFormat defines how StringList parameters are rendered
+	readListFormat string
+	// readListSeparator replaces the comma between joined StringList items
+	readListSeparator string
+	// readFormat is the output format, one of config.OutputFormats
+	readFormat string
+	// readK8sName is the name of the Kubernetes Secret and ConfigMap
+	readK8sName string
+	// readK8sNamespace is the namespace of the Kubernetes Secret and ConfigMap
+	readK8sNamespace string
+	// readOutputDir is the directory to write one file per parameter to
+	readOutputDir string
+	// readFileNaming defines how the files in readOutputDir are named
+	readFileNaming string
+	// readFileMode is the octal permission of written files
+	readFileMode string
+	// readFileModeSet is set if readFileMode was passed explicitly
+	readFileModeSet bool
+	// readFileOwner is the user name or ID that owns written files
+	readFileOwner string
+	// readFileGroup is the group name or ID of written files
+	readFileGroup string
+	// readMerge updates the managed block of an existing file instead of
+	// replacing the file
+	readMerge bool
+	// readChangedExitCode is the exit code if the output changed, 0 to exit
+	// with 0 either way
+	readChangedExitCode int
+	// readOnChange is a shell command that is run if the output changed
+	readOnChange string
+	// readSignal is sent to the process readPID or readPIDFile if the output
+	// changed
+	readSignal string
+	// readPID is the process that receives readSignal
+	readPID int
+	// readPIDFile contains the process that receives readSignal
+	readPIDFile string
+	// readWatch keeps running and reads the parameters every readInterval
+	readWatch bool
+	// readInterval is the time between two reads in watch mode
+	readInterval time.Duration
 )
 
+// Supported rendering modes for StringList parameters
+const (
+	// listFormatJoin renders all items into a single variable
+	listFormatJoin = "join"
+	// listFormatSplit renders one variable per item, suffixed with its index
+	listFormatSplit = "split"
+)
+
+// envVar is a single environment variable rendered from a parameter
+type envVar struct {
+	name  string
+	value string
+	// secure is set for values of SecureString parameters
+	secure bool
+	// format is the output format set for the parameter, empty for the
+	// format of the run
+	format string
+}
+
+// k8sNamePattern matches valid Kubernetes object names
+var k8sNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+
 // readCmd represents the read command
 var readCmd = &cobra.Command{
 	Use:   "read",
@@ -45,6 +120,42 @@ var readCmd = &cobra.Command{
 The parameter value will be printed to stdout in the format:
 export PARAM="value"
 
+Other output formats are selected with --format or per parameter with the
+output setting in the config file:
+  shell    export PARAM="value" (default)
+  docker   PARAM=value for docker run --env-file, multiline values are rejected
+  systemd  PARAM="value" for a systemd EnvironmentFile
+  k8s      Kubernetes Secret with the base64 encoded SecureString values and
+           ConfigMap with all other values, named by --k8s-name
+  github   appended to $GITHUB_ENV (or --file) in GitHub Actions, multiline
+           values use the heredoc syntax, SecureString values are masked
+  gitlab-dotenv
+           PARAM=value for a GitLab CI dotenv report, multiline values are
+           rejected
+
+Without --env, the variable name is derived from the parameter path by
+--env-naming: base uses the last segment, path the full path and relative
+the path below --strip-prefix. Characters like - and . become _, two
+parameters with the same name are an error.
+
+With --output-dir every value is written to its own file instead, named
+like the environment variable or with --file-naming path by the last
+segment of the parameter path. Files of parameters that were removed from
+the config are deleted on the next run.
+
+With --merge the variables are written to a marked block of the file and
+all other lines are kept. Assignments outside of the block to a variable the
+block sets are commented out. Variables that a previous run wrote, but this run
+doesn't, are removed from the block.
+
+Files are only written if their content changes. Use --on-change to run a
+command, --signal to signal a process or --changed-exit-code to exit with a
+distinct code in that case.
+
+With --watch the command keeps running and reads the parameters again every
+--interval, plus up to 10% random jitter. Failed reads are retried with
+exponential backoff. SIGTERM and SIGINT stop watching.
+
 Examples:
   # Read a single parameter
   params2env read --path /myapp/config/url
@@ -56,13 +167,139 @@ Examples:
   params2env read --path /myapp/config/url --env MY_URL
 
   # Read a parameter with prefix and uppercase name
-  params2env read --path /myapp/config/url --env-prefix MYAPP --upper`,
+  params2env read --path /myapp/config/url --env-prefix MYAPP --upper
+
+  # Name the variables by their path below /myapp/prod, e.g. DB_URL
+  params2env read --env-naming relative --strip-prefix /myapp/prod
+
+  # Read a StringList parameter into HOSTS_0, HOSTS_1, ...
+  params2env read --path /myapp/config/hosts --list-format split
+
+  # Read a StringList parameter joined with a custom separator
+  params2env read --path /myapp/config/hosts --list-separator " "
+
+  # Write a Docker env file
+  params2env read --path /myapp/config/url --format docker --file app.env
+
+  # Render a Kubernetes manifest
+  params2env read --format k8s --k8s-name myapp --k8s-namespace prod | kubectl apply -f -
+
+  # Export parameters to later steps of a GitHub Actions job
+  params2env read --path /myapp/config/url --format github
+
+  # Write a GitLab CI dotenv report
+  params2env read --path /myapp/config/url --format gitlab-dotenv --file build.env
+
+  # Write a file readable by the group of a service
+  params2env read --path /myapp/config/url --file /etc/myapp/env --file-mode 0640 --file-group myapp
+
+  # Update only the variables managed by params2env in an existing file
+  params2env read --path /myapp/config/url --file .env --merge
+
+  # Restart the service only if a value changed
+  params2env read --file /etc/myapp/env --on-change "systemctl restart myapp"
+
+  # Keep the file in sync and reload nginx on changes
+  params2env read --file /etc/nginx/env --watch --interval 5m --signal HUP --pid-file /run/nginx.pid
+
+  # Write one file per parameter, e.g. for systemd LoadCredential=
+  params2env read --output-dir /etc/credstore/myapp --file-naming path`,
 	PreRunE: validateReadFlags,
 	RunE:    runRead,
 }
 
 // validateReadFlags checks if all required flags are set and valid
 func validateReadFlags(cmd *cobra.Command, args []string) error {
+	if err := validateParameterFlags(); err != nil {
+		return err
+	}
+
+	if !slices.Contains(config.OutputFormats, readFormat) {
+		return fmt.Errorf("invalid output format: %s (must be one of %s)", readFormat, strings.Join(config.OutputFormats, ", "))
+	}
+
+	if !k8sNamePattern.MatchString(readK8sName) {
+		return fmt.Errorf("invalid Kubernetes name: %s", readK8sName)
+	}
+	if readK8sNamespace != "" && !k8sNamePattern.MatchString(readK8sNamespace) {
+		return fmt.Errorf("invalid Kubernetes namespace: %s", readK8sNamespace)
+	}
+
+	if readOutputDir != "" && readFile != "" {
+		return fmt.Errorf("\"file\" and \"output-dir\" are mutually exclusive")
+	}
+	if readOutputDir != "" && readMerge {
+		return fmt.Errorf("\"merge\" and \"output-dir\" are mutually exclusive")
+	}
+	if readOutputDir != "" && cmd.Flags().Changed("format") {
+		return fmt.Errorf("\"format\" can't be used with \"output-dir\", the files contain the plain values")
+	}
+	if readFileNaming != fileNamingEnv && readFileNaming != fileNamingPath {
+		return fmt.Errorf("invalid file naming: %s (must be '%s' or '%s')", readFileNaming, fileNamingEnv, fileNamingPath)
+	}
+
+	if _, err := parseOutputFileSettings(readFileMode, readFileOwner, readFileGroup); err != nil {
+		return err
+	}
+	readFileModeSet = cmd.Flags().Changed("file-mode")
+
+	// 1 is the exit code of errors, above 125 are reserved by shells
+	if readChangedExitCode < 0 || readChangedExitCode == 1 || readChangedExitCode > 125 {
+		return fmt.Errorf("invalid changed exit code: %d (must be between 2 and 125)", readChangedExitCode)
+	}
+
+	if readSignal != "" {
+		if _, err := parseSignal(readSignal); err != nil {
+			return err
+		}
+		if (readPID == 0) == (readPIDFile == "") {
+			return fmt.Errorf("\"signal\" requires either \"pid\" or \"pid-file\"")
+		}
+	} else if readPID != 0 || readPIDFile != "" {
+		return fmt.Errorf("\"pid\" and \"pid-file\" require \"signal\"")
+	}
+
+	if readWatch {
+		if readInterval <= 0 {
+			return fmt.Errorf("invalid interval: %s (must be positive)", readInterval)
+		}
+		if readChangedExitCode != 0 {
+			return fmt.Errorf("\"changed-exit-code\" and \"watch\" are mutually exclusive")
+		}
+	}
+
+	if readFormat == config.OutputGitHub && readOutputDir == "" {
+		if err := validateGitHubFlags(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateGitHubFlags rejects the flags that act on a changed output. The
+// github format is appended on every run, so it always changes.
+func validateGitHubFlags() error {
+	flags := []struct {
+		name string
+		set  bool
+	}{
+		{"changed-exit-code", readChangedExitCode != 0},
+		{"on-change", readOnChange != ""},
+		{"signal", readSignal != ""},
+		{"watch", readWatch},
+	}
+	for _, flag := range flags {
+		if flag.set {
+			return fmt.Errorf("\"%s\" can't be used with the github format, it's appended to on every run", flag.name)
+		}
+	}
+	return nil
+}
+
+// validateParameterFlags checks the flags that select the parameters and
+// name the environment variables, shared by the read and run commands
+func validateParameterFlags() error {
 	// Load config to check if parameters are defined
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -88,9 +325,64 @@ func validateReadFlags(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if readListFormat != "" && readListFormat != listFormatJoin && readListFormat != listFormatSplit {
+		return fmt.Errorf("invalid list format: %s (must be '%s' or '%s')", readListFormat, listFormatJoin, listFormatSplit)
+	}
+
+	return validateEnvNaming(cfg)
+}
+
+// validateEnvNaming checks the naming strategy and that all parameters are
+// below the strip prefix if it's used
+func validateEnvNaming(cfg *config.Config) error {
+	naming, stripPrefix := envNaming(cfg)
+	if !slices.Contains(config.EnvNamings, naming) {
+		return fmt.Errorf("invalid env naming: %s (must be one of %s)", naming, strings.Join(config.EnvNamings, ", "))
+	}
+	if naming != config.EnvNamingRelative {
+		return nil
+	}
+
+	if stripPrefix == "" {
+		return fmt.Errorf("env naming '%s' requires a strip prefix", config.EnvNamingRelative)
+	}
+	if err := validation.ValidateParameterPath(stripPrefix); err != nil {
+		return fmt.Errorf("invalid strip prefix: %w", err)
+	}
+
+	paths := []string{readPath}
+	if readPath == "" {
+		paths = paths[:0]
+		for _, param := range cfg.Params {
+			paths = append(paths, param.Name)
+		}
+	}
+	for _, p := range paths {
+		if !strings.HasPrefix(p, stripPrefix+"/") {
+			return fmt.Errorf("parameter '%s' is not below the strip prefix '%s'", p, stripPrefix)
+		}
+	}
 	return nil
 }
 
+// envNaming returns the naming strategy and the strip prefix, flags take
+// precedence over the config
+func envNaming(cfg *config.Config) (string, string) {
+	naming, stripPrefix := readEnvNaming, readStripPrefix
+	if cfg != nil {
+		if naming == "" {
+			naming = cfg.EnvNaming
+		}
+		if stripPrefix == "" {
+			stripPrefix = cfg.StripPrefix
+		}
+	}
+	if naming == "" {
+		naming = config.EnvNamingBase
+	}
+	return naming, stripPrefix
+}
+
 // runRead executes the read command
 func runRead(cmd *cobra.Command, args []string) error {
 	// Load configuration
@@ -99,55 +391,123 @@ func runRead(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
-	// If path is not set but we have params in config, use those
-	if readPath == "" && cfg != nil && len(cfg.Params) > 0 {
-		return handleConfigParameters(cfg)
+	// An output file given as flag takes precedence over the output
+	// directory from the config
+	if readOutputDir == "" && readFile == "" && cfg != nil {
+		readOutputDir = cfg.OutputDir
 	}
 
-	// Handle single parameter case
-	return handleSingleParameter(cfg)
+	readOnce := func() (bool, error) {
+		// If path is not set but we have params in config, use those
+		if readPath == "" && cfg != nil && len(cfg.Params) > 0 {
+			return handleConfigParameters(cfg)
+		}
+		// Handle single parameter case
+		return handleSingleParameter(cfg)
+	}
+
+	if readWatch {
+		// Stop cleanly on SIGTERM, e.g. from systemd, or Ctrl+C
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return watch(ctx, readInterval, readOnce)
+	}
+
+	changed, err := readOnce()
+	if err != nil || !changed {
+		return err
+	}
+	if err := notifyChange(); err != nil {
+		return err
+	}
+	if readChangedExitCode != 0 {
+		osExit(readChangedExitCode)
+	}
+	return nil
 }
 
 // handleConfigParameters processes parameters defined in the configuration
-func handleConfigParameters(cfg *config.Config) error {
-	var outputs []string
+func handleConfigParameters(cfg *config.Config) (bool, error) {
+	vars, err := resolveConfigParameters(cfg)
+	if err != nil {
+		return false, err
+	}
+	return emitOutput(readFormat, vars, cfg.Params, cfg)
+}
+
+// resolveConfigParameters reads the parameters defined in the configuration
+// and returns them as environment variables. Two parameters with the same
+// variable name are an error, one would silently overwrite the other.
+func resolveConfigParameters(cfg *config.Config) ([]envVar, error) {
+	var vars []envVar
+	sources := make(map[string]string)
 	for _, param := range cfg.Params {
 		// Get parameter value
-		value, err := getParameterValue(param.Name, param.Region, cfg.Region)
+		p, err := getParameter(param.Name, param.Region, cfg.Region)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		// Format the output
-		name := formatEnvName(param.Name, param.Env, cfg)
-		outputs = append(outputs, fmt.Sprintf("export %s=%q", name, value))
-	}
+		listFormat := readListFormat
+		if param.ListFormat != "" {
+			listFormat = param.ListFormat
+		}
+		separator := readListSeparator
+		if param.ListSeparator != "" {
+			separator = param.ListSeparator
+		}
 
-	output := strings.Join(outputs, "\n") + "\n"
-	return writeOutput(output, cfg.Params, cfg)
+		name := outputName(param.Name, param.Env, cfg)
+		for _, v := range expandParameter(name, p, listFormat, separator) {
+			if source, ok := sources[v.name]; ok {
+				return nil, fmt.Errorf("parameters '%s' and '%s' both map to the name '%s'", source, param.Name, v.name)
+			}
+			sources[v.name] = param.Name
+			v.format = param.Output
+			vars = append(vars, v)
+		}
+	}
+	return vars, nil
 }
 
 // handleSingleParameter processes a single parameter specified via command line
-func handleSingleParameter(cfg *config.Config) error {
+func handleSingleParameter(cfg *config.Config) (bool, error) {
+	vars, err := resolveSingleParameter(cfg)
+	if err != nil {
+		return false, err
+	}
+	return emitOutput(readFormat, vars, []config.ParamConfig{{Name: readPath}}, cfg)
+}
+
+// resolveSingleParameter reads the parameter specified via command line and
+// returns it as environment variables
+func resolveSingleParameter(cfg *config.Config) ([]envVar, error) {
 	// Merge config with flags (flags take precedence)
 	mergeReadConfig(cfg)
 
 	// Ensure region is set
 	if err := ensureReadRegionIsSet(); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Get parameter value
-	value, err := getParameterValue(readPath, readRegion, "")
+	p, err := getParameter(readPath, readRegion, "")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Format the output
-	name := formatEnvName(readPath, readEnvName, cfg)
-	output := fmt.Sprintf("export %s=%q\n", name, value)
+	name := outputName(readPath, readEnvName, cfg)
+	return expandParameter(name, p, readListFormat, readListSeparator), nil
+}
 
-	return writeOutput(output, []config.ParamConfig{{Name: readPath}}, cfg)
+// resolveParameters reads the parameter specified via command line or, if
+// there is none, the parameters defined in the configuration
+func resolveParameters(cfg *config.Config) ([]envVar, error) {
+	if readPath == "" && cfg != nil && len(cfg.Params) > 0 {
+		return resolveConfigParameters(cfg)
+	}
+	return resolveSingleParameter(cfg)
 }
 
 // mergeReadConfig merges configuration from file with command line flags
@@ -182,8 +542,8 @@ func ensureReadRegionIsSet() error {
 	return nil
 }
 
-// getParameterValue retrieves a parameter value from SSM Parameter Store
-func getParameterValue(paramName, paramRegion, defaultRegion string) (string, error) {
+// getParameter retrieves a parameter and its type from SSM Parameter Store
+func getParameter(paramName, paramRegion, defaultRegion string) (*aws.Parameter, error) {
 	region := paramRegion
 	if region == "" {
 		region = defaultRegion
@@ -192,38 +552,54 @@ func getParameterValue(paramName, paramRegion, defaultRegion string) (string, er
 		region = os.Getenv("AWS_REGION")
 	}
 	if region == "" {
-		return "", fmt.Errorf("AWS region must be specified via config, --region, or AWS_REGION environment variable")
+		return nil, fmt.Errorf("AWS region must be specified via config, --region, or AWS_REGION environment variable")
 	}
 
 	ctx := context.Background()
 	client, err := aws.NewClient(ctx, region, readRole)
 	if err != nil {
-		return "", fmt.Errorf("failed to create AWS client: %w", err)
+		return nil, fmt.Errorf("failed to create AWS client: %w", err)
 	}
 
-	value, err := client.GetParameter(ctx, paramName)
+	param, err := client.GetParameterInfo(ctx, paramName)
 	if err != nil {
 		if errors.Is(err, aws.ErrNotFound) {
-			return "", fmt.Errorf("parameter '%s' not found in region '%s'", paramName, region)
+			return nil, fmt.Errorf("parameter '%s' not found in region '%s'", paramName, region)
 		}
 		if errors.Is(err, aws.ErrNoAccess) {
-			return "", fmt.Errorf("access denied to parameter '%s' in region '%s': check IAM permissions", paramName, region)
+			return nil, fmt.Errorf("access denied to parameter '%s' in region '%s': check IAM permissions", paramName, region)
 		}
 		// Check for throttling errors by examining error message
 		if strings.Contains(err.Error(), "throttl") {
-			return "", fmt.Errorf("request throttled for parameter '%s' in region '%s': try again later", paramName, region)
+			return nil, fmt.Errorf("request throttled for parameter '%s' in region '%s': try again later", paramName, region)
 		}
-		return "", fmt.Errorf("failed to get parameter '%s' from region '%s': %w", paramName, region, err)
+		return nil, fmt.Errorf("failed to get parameter '%s' from region '%s': %w", paramName, region, err)
 	}
 
-	return value, nil
+	return param, nil
 }
 
-// formatEnvName formats the environment variable name according to configuration
+// envNameReplacer replaces the characters of parameter paths that aren't
+// valid in environment variable names
+var envNameReplacer = strings.NewReplacer("/", "_", "-", "_", ".", "_")
+
+// formatEnvName formats the environment variable name according to configuration.
+// Without an explicit name it's derived from the path by the naming
+// strategy, e.g. /app/db-url becomes DB_URL, APP_DB_URL or, with the strip
+// prefix /app, DB_URL.
 func formatEnvName(paramPath, envName string, cfg *config.Config) string {
 	name := envName
 	if name == "" {
-		name = filepath.Base(paramPath)
+		naming, stripPrefix := envNaming(cfg)
+		switch naming {
+		case config.EnvNamingPath:
+			name = strings.TrimPrefix(paramPath, "/")
+		case config.EnvNamingRelative:
+			name = strings.TrimPrefix(strings.TrimPrefix(paramPath, stripPrefix), "/")
+		default:
+			name = path.Base(paramPath)
+		}
+		name = envNameReplacer.Replace(name)
 	}
 
 	if readPrefix != "" {
@@ -232,6 +608,11 @@ func formatEnvName(paramPath, envName string, cfg *config.Config) string {
 		name = cfg.EnvPrefix + "_" + name
 	}
 
+	// Variable names must not start with a digit
+	if envName == "" && name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+
 	if readUpper {
 		name = strings.ToUpper(name)
 	}
@@ -239,22 +620,134 @@ func formatEnvName(paramPath, envName string, cfg *config.Config) string {
 	return name
 }
 
-// writeOutput writes the parameter value(s) to a file or stdout.
+// expandParameter converts a parameter into environment variables.
+// StringList parameters are either joined into one variable, optionally using
+// a custom separator, or split into one variable per item named NAME_0, NAME_1, ...
+// All other parameter types result in a single variable.
+func expandParameter(name string, param *aws.Parameter, listFormat, separator string) []envVar {
+	if param.Type != aws.ParameterTypeStringList {
+		return []envVar{{name: name, value: param.Value, secure: param.Type == aws.ParameterTypeSecureString}}
+	}
+
+	items := strings.Split(param.Value, ",")
+	if listFormat == listFormatSplit {
+		vars := make([]envVar, 0, len(items))
+		for i, item := range items {
+			vars = append(vars, envVar{name: fmt.Sprintf("%s_%d", name, i), value: item})
+		}
+		return vars
+	}
+
+	if separator != "" {
+		return []envVar{{name: name, value: strings.Join(items, separator)}}
+	}
+	return []envVar{{name: name, value: param.Value}}
+}
+
+// formatExports renders environment variables as shell export statements
+func formatExports(vars []envVar) string {
+	var b strings.Builder
+	for _, v := range vars {
+		fmt.Fprintf(&b, "export %s=%q\n", v.name, v.value)
+	}
+	return b.String()
+}
+
+// emitOutput renders the variables in their format, or the given format if
+// they have none, and writes them. With an output directory every value is
+// written to its own file. The github format is appended to the GitHub
+// Actions environment file, all other formats are written to a file or
+// stdout.
+func emitOutput(format string, vars []envVar, params []config.ParamConfig, cfg *config.Config) (bool, error) {
+	if readOutputDir != "" {
+		settings, err := parseOutputFileSettings(readFileMode, readFileOwner, readFileGroup)
+		if err != nil {
+			return false, err
+		}
+		return writeOutputDir(readOutputDir, vars, params, settings)
+	}
+
+	formats := varFormats(format, vars)
+	output, err := renderGroups(format, vars)
+	if err != nil {
+		return false, err
+	}
+	if readMerge {
+		for _, f := range formats {
+			if err := validateMergeFormat(f); err != nil {
+				return false, err
+			}
+		}
+	}
+	if formats[0] == config.OutputGitHub {
+		if err := validateGitHubFlags(); err != nil {
+			return false, err
+		}
+		return appendGitHubEnv(output, vars, params, cfg)
+	}
+	return writeOutput(output, params, cfg)
+}
+
+// appendGitHubEnv appends the rendered variables to the GitHub Actions
+// environment file, which is --file, the file from the config or
+// $GITHUB_ENV. SecureString values are masked in the workflow log first,
+// before any later step can print them.
+func appendGitHubEnv(output string, vars []envVar, params []config.ParamConfig, cfg *config.Config) (bool, error) {
+	file := readFile
+	if file == "" && cfg != nil {
+		file = cfg.File
+	}
+	if file == "" {
+		file = os.Getenv("GITHUB_ENV")
+	}
+	if file == "" {
+		return false, fmt.Errorf("the github format requires --file or the GITHUB_ENV environment variable")
+	}
+
+	fmt.Print(formatGitHubMasks(vars))
+	for _, param := range params {
+		fmt.Printf("Reading parameter '%s' from region '%s'\n", param.Name, readRegion)
+	}
+
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return false, fmt.Errorf("failed to open file: %w", err)
+	}
+	if _, err := f.WriteString(output); err != nil {
+		_ = f.Close()
+		return false, fmt.Errorf("failed to write to file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return false, fmt.Errorf("failed to write to file: %w", err)
+	}
+	fmt.Printf("Parameter value appended to %s\n", file)
+	return true, nil
+}
+
+// writeOutput writes the parameter value(s) to a file or stdout. A file is
+// only written if its content changes.
 // When writing to files, secure permissions are used to protect sensitive SSM parameter values:
 // - Directories: 0700 (owner access only) to prevent unauthorized directory traversal
 // - Files: 0600 (owner read/write only) to prevent unauthorized access to secrets
-func writeOutput(output string, params []config.ParamConfig, cfg *config.Config) error {
+func writeOutput(output string, params []config.ParamConfig, cfg *config.Config) (bool, error) {
 	if readFile == "" && cfg != nil {
 		readFile = cfg.File
 	}
+	if readFile == "" && readMerge {
+		return false, fmt.Errorf("\"merge\" requires a file")
+	}
 
 	if readFile != "" {
+		settings, err := parseOutputFileSettings(readFileMode, readFileOwner, readFileGroup)
+		if err != nil {
+			return false, err
+		}
+
 		// Ensure directory exists with secure permissions (0700 - owner access only)
 		// This prevents other users from accessing the directory containing sensitive parameter files
 		dir := filepath.Dir(readFile)
-		// Ensure directory exists with secure permissions (0700 = owner full access only)
 		if err := os.MkdirAll(dir, 0700); err != nil {
-			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+			return false, fmt.Errorf("failed to create directory %s: %w", dir, err)
 		}
 
 		// Print reading messages for each parameter
@@ -262,25 +755,96 @@ func writeOutput(output string, params []config.ParamConfig, cfg *config.Config)
 			fmt.Printf("Reading parameter '%s' from region '%s'\n", param.Name, readRegion)
 		}
 
-		// Write to file with secure permissions (0600 - owner read/write only)
-		// This prevents other users from reading sensitive SSM parameter values
-		if err := os.WriteFile(readFile, []byte(output), 0600); err != nil {
-			return fmt.Errorf("failed to write to file: %w", err)
+		// Concurrent runs writing the same file wait for each other
+		unlock, err := lockOutput(readFile)
+		if err != nil {
+			return false, err
+		}
+		defer func() { _ = unlock() }()
+
+		if readMerge {
+			// Other services may read the merged file, it keeps its mode
+			// and owner unless they are set explicitly
+			settings, err = existingFileSettings(readFile, settings, readFileModeSet)
+			if err != nil {
+				return false, err
+			}
+
+			existing, err := os.ReadFile(readFile)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return false, fmt.Errorf("failed to read %s: %w", readFile, err)
+			}
+			merged, disabled, err := mergeManagedBlock(string(existing), output)
+			if err != nil {
+				return false, fmt.Errorf("failed to merge into %s: %w", readFile, err)
+			}
+			output = merged
+			if len(disabled) > 0 {
+				fmt.Printf("Commented out %s outside of the managed block in %s\n", strings.Join(disabled, ", "), readFile)
+			}
+		}
+
+		unchanged, err := fileUnchanged(readFile, []byte(output), settings)
+		if err != nil {
+			return false, err
+		}
+		if unchanged {
+			fmt.Printf("Parameter value in %s is unchanged\n", readFile)
+			return false, nil
+		}
+
+		// Replace the file atomically, so readers never see a partial file.
+		// The default permissions (0600 - owner read/write only) prevent
+		// other users from reading sensitive SSM parameter values.
+		if err := writeOutputFile(readFile, []byte(output), settings); err != nil {
+			return false, fmt.Errorf("failed to write to file: %w", err)
 		}
 		fmt.Printf("Parameter value written to %s\n", readFile)
-		return nil
+		return true, nil
+	}
+
+	// Without a file there is nothing to compare with
+	if readChangedExitCode != 0 || readOnChange != "" || readSignal != "" || readWatch {
+		return false, fmt.Errorf("\"changed-exit-code\", \"on-change\", \"signal\" and \"watch\" require a file")
 	}
 
 	fmt.Print(output)
-	return nil
+	return true, nil
+}
+
+// addParameterFlags adds the flags that select the parameters and name the
+// environment variables. The read and run commands resolve parameters the
+// same way, so they share these flags.
+func addParameterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&readPath, "path", "", "Parameter path (required if no parameters defined in config)")
+	cmd.Flags().StringVar(&readRegion, "region", "", "AWS region (optional, default: from AWS config or environment)")
+	cmd.Flags().StringVar(&readRole, "role", "", "AWS role ARN to assume (optional)")
+	cmd.Flags().BoolVar(&readUpper, "upper", true, "Convert env var name to uppercase")
+	cmd.Flags().StringVar(&readPrefix, "env-prefix", "", "Prefix for env var name")
+	cmd.Flags().StringVar(&readEnvName, "env", "", "Environment variable name")
+	cmd.Flags().StringVar(&readEnvNaming, "env-naming", "", "Naming of env vars derived from paths (base, path or relative) (default: base)")
+	cmd.Flags().StringVar(&readStripPrefix, "strip-prefix", "", "Path prefix removed by the relative env naming (optional)")
+	cmd.Flags().StringVar(&readListFormat, "list-format", "", "Rendering of StringList parameters (join or split)")
+	cmd.Flags().StringVar(&readListSeparator, "list-separator", "", "Separator for joined StringList items (default: ',')")
 }
 
 func init() {
-	readCmd.Flags().StringVar(&readPath, "path", "", "Parameter path (required if no parameters defined in config)")
-	readCmd.Flags().StringVar(&readRegion, "region", "", "AWS region (optional, default: from AWS config or environment)")
-	readCmd.Flags().StringVar(&readRole, "role", "", "AWS role ARN to assume (optional)")
+	addParameterFlags(readCmd)
 	readCmd.Flags().StringVar(&readFile, "file", "", "File to write to (optional)")
-	readCmd.Flags().BoolVar(&readUpper, "upper", true, "Convert env var name to uppercase")
-	readCmd.Flags().StringVar(&readPrefix, "env-prefix", "", "Prefix for env var name")
-	readCmd.Flags().StringVar(&readEnvName, "env", "", "Environment variable name")
+	readCmd.Flags().StringVar(&readFormat, "format", config.OutputShell, "Output format (shell, docker, systemd, k8s, github or gitlab-dotenv)")
+	readCmd.Flags().StringVar(&readK8sName, "k8s-name", "params2env", "Name of the Kubernetes Secret and ConfigMap")
+	readCmd.Flags().StringVar(&readK8sNamespace, "k8s-namespace", "", "Namespace of the Kubernetes Secret and ConfigMap (optional)")
+	readCmd.Flags().StringVar(&readOutputDir, "output-dir", "", "Directory to write one file per parameter to (optional)")
+	readCmd.Flags().StringVar(&readFileNaming, "file-naming", fileNamingEnv, "Naming of the files in the output directory (env or path)")
+	readCmd.Flags().StringVar(&readFileMode, "file-mode", "0600", "Octal permissions of written files")
+	readCmd.Flags().StringVar(&readFileOwner, "file-owner", "", "User name or ID that owns written files (optional)")
+	readCmd.Flags().StringVar(&readFileGroup, "file-group", "", "Group name or ID of written files (optional)")
+	readCmd.Flags().BoolVar(&readMerge, "merge", false, "Update the managed block of an existing file instead of replacing it")
+	readCmd.Flags().IntVar(&readChangedExitCode, "changed-exit-code", 0, "Exit code if the output changed (optional)")
+	readCmd.Flags().StringVar(&readOnChange, "on-change", "", "Shell command to run if the output changed (optional)")
+	readCmd.Flags().StringVar(&readSignal, "signal", "", "Signal to send to --pid or --pid-file if the output changed (optional)")
+	readCmd.Flags().IntVar(&readPID, "pid", 0, "Process that receives --signal (optional)")
+	readCmd.Flags().StringVar(&readPIDFile, "pid-file", "", "File with the process ID that receives --signal (optional)")
+	readCmd.Flags().BoolVar(&readWatch, "watch", false, "Keep running and read the parameters every --interval")
+	readCmd.Flags().DurationVar(&readInterval, "interval", 5*time.Minute, "Time between two reads in watch mode")
 }
@@ -11,7 +11,10 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"git.sr.ht/~wombelix/params2env/internal/aws"
 	"git.sr.ht/~wombelix/params2env/internal/config"
@@ -77,6 +80,26 @@ func setupReadFlags(t *testing.T, testRoot *cobra.Command) {
 	readCmd.Flags().BoolVar(&readUpper, "upper", true, "Convert env var name to uppercase")
 	readCmd.Flags().StringVar(&readPrefix, "env-prefix", "", "Prefix for env var name")
 	readCmd.Flags().StringVar(&readEnvName, "env", "", "Environment variable name")
+	readCmd.Flags().StringVar(&readEnvNaming, "env-naming", "", "Env naming")
+	readCmd.Flags().StringVar(&readStripPrefix, "strip-prefix", "", "Strip prefix")
+	readCmd.Flags().StringVar(&readFormat, "format", config.OutputShell, "Output format")
+	readCmd.Flags().StringVar(&readK8sName, "k8s-name", "params2env", "Kubernetes object name")
+	readCmd.Flags().StringVar(&readK8sNamespace, "k8s-namespace", "", "Kubernetes namespace")
+	readCmd.Flags().StringVar(&readOutputDir, "output-dir", "", "Output directory")
+	readCmd.Flags().StringVar(&readFileNaming, "file-naming", fileNamingEnv, "File naming")
+	readCmd.Flags().StringVar(&readFileMode, "file-mode", "0600", "File mode")
+	readCmd.Flags().StringVar(&readFileOwner, "file-owner", "", "File owner")
+	readCmd.Flags().StringVar(&readFileGroup, "file-group", "", "File group")
+	readCmd.Flags().BoolVar(&readMerge, "merge", false, "Merge into file")
+	readCmd.Flags().IntVar(&readChangedExitCode, "changed-exit-code", 0, "Changed exit code")
+	readCmd.Flags().StringVar(&readOnChange, "on-change", "", "On change command")
+	readCmd.Flags().StringVar(&readSignal, "signal", "", "Signal")
+	readCmd.Flags().IntVar(&readPID, "pid", 0, "PID")
+	readCmd.Flags().StringVar(&readPIDFile, "pid-file", "", "PID file")
+	readCmd.Flags().BoolVar(&readWatch, "watch", false, "Watch")
+	readCmd.Flags().DurationVar(&readInterval, "interval", 5*time.Minute, "Interval")
+	readCmd.Flags().StringVar(&readListFormat, "list-format", "", "Rendering of StringList parameters")
+	readCmd.Flags().StringVar(&readListSeparator, "list-separator", "", "Separator for joined StringList items")
 	if err := readCmd.MarkFlagRequired("path"); err != nil {
 		t.Fatalf("Failed to mark path flag as required: %v", err)
 	}
@@ -86,6 +109,7 @@ func setupReadFlags(t *testing.T, testRoot *cobra.Command) {
 func TestRunRead(t *testing.T) {
 	rts := setupReadTest(t)
 	defer rts.cleanup()
+	outDir := t.TempDir()
 
 	tests := []struct {
 		name       string
@@ -117,7 +141,7 @@ func TestRunRead(t *testing.T) {
 		},
 		{
 			name:       "read_with_file",
-			args:       []string{"--path", "/test/param", "--region", "us-west-2", "--file", "test.txt"},
+			args:       []string{"--path", "/test/param", "--region", "us-west-2", "--file", filepath.Join(outDir, "test.txt")},
 			wantOutput: "",
 		},
 		{
@@ -253,6 +277,7 @@ func TestRunRead(t *testing.T) {
 func TestRunReadWithConfig(t *testing.T) {
 	rts := setupReadTest(t)
 	defer rts.cleanup()
+	outDir := t.TempDir()
 
 	// Override mock client for config test
 	mockClient := &aws.MockSSMClient{
@@ -308,7 +333,7 @@ params:
 		},
 		{
 			name:       "write_to_file",
-			args:       []string{"--file", "test.env"},
+			args:       []string{"--file", filepath.Join(outDir, "test.env")},
 			wantOutput: "",
 			wantErr:    false,
 		},
@@ -325,6 +350,24 @@ params:
 			readCmd.Flags().BoolVar(&readUpper, "upper", true, "Convert env var name to uppercase")
 			readCmd.Flags().StringVar(&readPrefix, "env-prefix", "", "Prefix for env var name")
 			readCmd.Flags().StringVar(&readEnvName, "env", "", "Environment variable name")
+			readCmd.Flags().StringVar(&readEnvNaming, "env-naming", "", "Env naming")
+			readCmd.Flags().StringVar(&readStripPrefix, "strip-prefix", "", "Strip prefix")
+			readCmd.Flags().StringVar(&readFormat, "format", config.OutputShell, "Output format")
+			readCmd.Flags().StringVar(&readK8sName, "k8s-name", "params2env", "Kubernetes object name")
+			readCmd.Flags().StringVar(&readK8sNamespace, "k8s-namespace", "", "Kubernetes namespace")
+			readCmd.Flags().StringVar(&readOutputDir, "output-dir", "", "Output directory")
+			readCmd.Flags().StringVar(&readFileNaming, "file-naming", fileNamingEnv, "File naming")
+			readCmd.Flags().StringVar(&readFileMode, "file-mode", "0600", "File mode")
+			readCmd.Flags().StringVar(&readFileOwner, "file-owner", "", "File owner")
+			readCmd.Flags().StringVar(&readFileGroup, "file-group", "", "File group")
+			readCmd.Flags().BoolVar(&readMerge, "merge", false, "Merge into file")
+			readCmd.Flags().IntVar(&readChangedExitCode, "changed-exit-code", 0, "Changed exit code")
+			readCmd.Flags().StringVar(&readOnChange, "on-change", "", "On change command")
+			readCmd.Flags().StringVar(&readSignal, "signal", "", "Signal")
+			readCmd.Flags().IntVar(&readPID, "pid", 0, "PID")
+			readCmd.Flags().StringVar(&readPIDFile, "pid-file", "", "PID file")
+			readCmd.Flags().BoolVar(&readWatch, "watch", false, "Watch")
+			readCmd.Flags().DurationVar(&readInterval, "interval", 5*time.Minute, "Interval")
 			testRoot.AddCommand(readCmd)
 
 			oldStdout := os.Stdout
@@ -408,6 +451,24 @@ params:
 	readCmd.Flags().BoolVar(&readUpper, "upper", true, "Convert env var name to uppercase")
 	readCmd.Flags().StringVar(&readPrefix, "env-prefix", "", "Prefix for env var name")
 	readCmd.Flags().StringVar(&readEnvName, "env", "", "Environment variable name")
+	readCmd.Flags().StringVar(&readEnvNaming, "env-naming", "", "Env naming")
+	readCmd.Flags().StringVar(&readStripPrefix, "strip-prefix", "", "Strip prefix")
+	readCmd.Flags().StringVar(&readFormat, "format", config.OutputShell, "Output format")
+	readCmd.Flags().StringVar(&readK8sName, "k8s-name", "params2env", "Kubernetes object name")
+	readCmd.Flags().StringVar(&readK8sNamespace, "k8s-namespace", "", "Kubernetes namespace")
+	readCmd.Flags().StringVar(&readOutputDir, "output-dir", "", "Output directory")
+	readCmd.Flags().StringVar(&readFileNaming, "file-naming", fileNamingEnv, "File naming")
+	readCmd.Flags().StringVar(&readFileMode, "file-mode", "0600", "File mode")
+	readCmd.Flags().StringVar(&readFileOwner, "file-owner", "", "File owner")
+	readCmd.Flags().StringVar(&readFileGroup, "file-group", "", "File group")
+	readCmd.Flags().BoolVar(&readMerge, "merge", false, "Merge into file")
+	readCmd.Flags().IntVar(&readChangedExitCode, "changed-exit-code", 0, "Changed exit code")
+	readCmd.Flags().StringVar(&readOnChange, "on-change", "", "On change command")
+	readCmd.Flags().StringVar(&readSignal, "signal", "", "Signal")
+	readCmd.Flags().IntVar(&readPID, "pid", 0, "PID")
+	readCmd.Flags().StringVar(&readPIDFile, "pid-file", "", "PID file")
+	readCmd.Flags().BoolVar(&readWatch, "watch", false, "Watch")
+	readCmd.Flags().DurationVar(&readInterval, "interval", 5*time.Minute, "Interval")
 
 	// Add read command to test root
 	testRoot.AddCommand(readCmd)
@@ -422,7 +483,7 @@ params:
 	}
 }
 
-// TestErrorMessageFormatting tests the error message formatting logic in getParameterValue
+// TestErrorMessageFormatting tests the error message formatting logic in getParameter
 // to ensure proper context enrichment and actionable guidance without AWS mocking.
 func TestErrorMessageFormatting(t *testing.T) {
 	// Save original NewClient and restore after tests
@@ -477,18 +538,18 @@ func TestErrorMessageFormatting(t *testing.T) {
 				}}, nil
 			}
 
-			// Test getParameterValue function directly
-			_, err := getParameterValue(tt.paramName, tt.region, "")
+			// Test getParameter function directly
+			_, err := getParameter(tt.paramName, tt.region, "")
 
 			// Verify error occurred
 			if err == nil {
-				t.Errorf("getParameterValue() expected error but got none")
+				t.Errorf("getParameter() expected error but got none")
 				return
 			}
 
 			// Verify error message formatting
 			if !containsString(err.Error(), tt.expectedFormat) {
-				t.Errorf("getParameterValue() error = %q, expected to contain %q", err.Error(), tt.expectedFormat)
+				t.Errorf("getParameter() error = %q, expected to contain %q", err.Error(), tt.expectedFormat)
 			}
 		})
 	}
@@ -526,7 +587,7 @@ func TestSecureFilePermissions(t *testing.T) {
 			defer func() { readFile = origReadFile }()
 
 			// Call writeOutput to create file with secure permissions
-			err := writeOutput(output, params, nil)
+			_, err := writeOutput(output, params, nil)
 			if err != nil {
 				t.Fatalf("writeOutput failed: %v", err)
 			}
@@ -567,3 +628,477 @@ func TestSecureFilePermissions(t *testing.T) {
 		})
 	}
 }
+
+func TestExpandParameter(t *testing.T) {
+	tests := []struct {
+		name       string
+		param      *aws.Parameter
+		listFormat string
+		separator  string
+		want       []envVar
+	}{
+		{
+			name:  "string_parameter",
+			param: &aws.Parameter{Type: aws.ParameterTypeString, Value: "a,b"},
+			want:  []envVar{{name: "PARAM", value: "a,b"}},
+		},
+		{
+			name:  "string_list_default_join",
+			param: &aws.Parameter{Type: aws.ParameterTypeStringList, Value: "a,b,c"},
+			want:  []envVar{{name: "PARAM", value: "a,b,c"}},
+		},
+		{
+			name:       "string_list_join_with_separator",
+			param:      &aws.Parameter{Type: aws.ParameterTypeStringList, Value: "a,b,c"},
+			listFormat: listFormatJoin,
+			separator:  " ",
+			want:       []envVar{{name: "PARAM", value: "a b c"}},
+		},
+		{
+			name:       "string_list_split",
+			param:      &aws.Parameter{Type: aws.ParameterTypeStringList, Value: "a,b,c"},
+			listFormat: listFormatSplit,
+			want: []envVar{
+				{name: "PARAM_0", value: "a"},
+				{name: "PARAM_1", value: "b"},
+				{name: "PARAM_2", value: "c"},
+			},
+		},
+		{
+			name:       "split_ignored_for_string",
+			param:      &aws.Parameter{Type: aws.ParameterTypeString, Value: "a,b"},
+			listFormat: listFormatSplit,
+			want:       []envVar{{name: "PARAM", value: "a,b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandParameter("PARAM", tt.param, tt.listFormat, tt.separator)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandParameter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunReadStringList(t *testing.T) {
+	rts := setupReadTest(t)
+	defer rts.cleanup()
+	defer func() {
+		readListFormat = ""
+		readListSeparator = ""
+	}()
+
+	aws.NewClient = func(ctx context.Context, region, role string) (*aws.Client, error) {
+		return &aws.Client{SSMClient: &aws.MockSSMClient{
+			GetParamFunc: func(ctx context.Context, input *ssm.GetParameterInput, opts ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+				value := "host1,host2"
+				return &ssm.GetParameterOutput{
+					Parameter: &types.Parameter{
+						Value: &value,
+						Type:  types.ParameterTypeStringList,
+					},
+				}, nil
+			},
+		}}, nil
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantOutput string
+		wantErr    bool
+	}{
+		{
+			name:       "default_join",
+			args:       []string{"--path", "/test/hosts"},
+			wantOutput: "export HOSTS=\"host1,host2\"\n",
+		},
+		{
+			name:       "custom_separator",
+			args:       []string{"--path", "/test/hosts", "--list-separator", ":"},
+			wantOutput: "export HOSTS=\"host1:host2\"\n",
+		},
+		{
+			name:       "split",
+			args:       []string{"--path", "/test/hosts", "--list-format", "split"},
+			wantOutput: "export HOSTS_0=\"host1\"\nexport HOSTS_1=\"host2\"\n",
+		},
+		{
+			name:    "invalid_list_format",
+			args:    []string{"--path", "/test/hosts", "--list-format", "array"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testRoot := &cobra.Command{Use: "params2env"}
+			setupReadFlags(t, testRoot)
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			testRoot.SetArgs(append([]string{"read"}, tt.args...))
+			err := testRoot.Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("Failed to read captured output: %v", err)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runRead() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantOutput != "" && buf.String() != tt.wantOutput {
+				t.Errorf("runRead() output = %q, want %q", buf.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestRunReadFormat(t *testing.T) {
+	rts := setupReadTest(t)
+	defer rts.cleanup()
+	defer func() {
+		readFormat, readK8sName, readK8sNamespace = config.OutputShell, "params2env", ""
+	}()
+
+	aws.NewClient = func(ctx context.Context, region, role string) (*aws.Client, error) {
+		return &aws.Client{SSMClient: &aws.MockSSMClient{
+			GetParamFunc: func(ctx context.Context, input *ssm.GetParameterInput, opts ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+				value := "secret"
+				return &ssm.GetParameterOutput{
+					Parameter: &types.Parameter{Value: &value, Type: types.ParameterTypeSecureString},
+				}, nil
+			},
+		}}, nil
+	}
+
+	tests := []struct {
+		name       string
+		config     string
+		args       []string
+		wantOutput string
+		errMsg     string
+	}{
+		{
+			name:       "docker_flag",
+			args:       []string{"--path", "/app/password", "--format", "docker"},
+			wantOutput: "PASSWORD=secret\n",
+		},
+		{
+			name:       "k8s_flag",
+			args:       []string{"--path", "/app/password", "--format", "k8s", "--k8s-name", "myapp"},
+			wantOutput: "apiVersion: v1\nkind: Secret\nmetadata:\n    name: myapp\ntype: Opaque\ndata:\n    PASSWORD: c2VjcmV0\n",
+		},
+		{
+			name:       "config_output",
+			config:     "params:\n  - name: /app/password\n    output: systemd\n",
+			wantOutput: "PASSWORD=\"secret\"\n",
+		},
+		{
+			name:       "config_mixed_outputs",
+			config:     "params:\n  - name: /app/password\n    output: systemd\n  - name: /app/url\n",
+			wantOutput: "PASSWORD=\"secret\"\nexport URL=\"secret\"\n",
+		},
+		{
+			name:   "config_k8s_mixed",
+			config: "params:\n  - name: /app/password\n    output: k8s\n  - name: /app/url\n",
+			errMsg: "the k8s format can't be combined with other formats (k8s, shell)",
+		},
+		{
+			name:   "invalid_format",
+			args:   []string{"--path", "/app/password", "--format", "xml"},
+			errMsg: "invalid output format",
+		},
+		{
+			name:   "invalid_k8s_name",
+			args:   []string{"--path", "/app/password", "--format", "k8s", "--k8s-name", "My_App"},
+			errMsg: "invalid Kubernetes name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := filepath.Join(rts.tmpDir, ".params2env.yaml")
+			_ = os.Remove(configFile)
+			if tt.config != "" {
+				if err := os.WriteFile(configFile, []byte(tt.config), 0600); err != nil {
+					t.Fatalf("Failed to write config file: %v", err)
+				}
+			}
+
+			testRoot := &cobra.Command{Use: "params2env"}
+			setupReadFlags(t, testRoot)
+			if tt.config != "" {
+				// The path is optional with parameters in the config file
+				readCmd.Flags().Lookup("path").Annotations = nil
+			}
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			testRoot.SetArgs(append([]string{"read"}, tt.args...))
+			err := testRoot.Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, r); err != nil {
+				t.Fatalf("Failed to read captured output: %v", err)
+			}
+
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("runRead() error = %v, want error containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runRead() error = %v", err)
+			}
+			if buf.String() != tt.wantOutput {
+				t.Errorf("runRead() output = %q, want %q", buf.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestRunReadGitHub(t *testing.T) {
+	rts := setupReadTest(t)
+	defer rts.cleanup()
+	defer func() { readFormat = config.OutputShell }()
+
+	aws.NewClient = func(ctx context.Context, region, role string) (*aws.Client, error) {
+		return &aws.Client{SSMClient: &aws.MockSSMClient{
+			GetParamFunc: func(ctx context.Context, input *ssm.GetParameterInput, opts ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+				value := "secret"
+				return &ssm.GetParameterOutput{
+					Parameter: &types.Parameter{Value: &value, Type: types.ParameterTypeSecureString},
+				}, nil
+			},
+		}}, nil
+	}
+
+	envFile := filepath.Join(rts.tmpDir, "github_env")
+	if err := os.WriteFile(envFile, []byte("EXISTING=1\n"), 0600); err != nil {
+		t.Fatalf("Failed to write env file: %v", err)
+	}
+	t.Setenv("GITHUB_ENV", envFile)
+
+	testRoot := &cobra.Command{Use: "params2env"}
+	setupReadFlags(t, testRoot)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	testRoot.SetArgs([]string{"read", "--path", "/app/password", "--format", "github"})
+	err := testRoot.Execute()
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		t.Fatalf("Failed to read captured output: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("runRead() error = %v", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), "::add-mask::secret\n") {
+		t.Errorf("runRead() output = %q, want it to start with the mask", buf.String())
+	}
+	content, err := os.ReadFile(envFile)
+	if err != nil {
+		t.Fatalf("Failed to read env file: %v", err)
+	}
+	if string(content) != "EXISTING=1\nPASSWORD=secret\n" {
+		t.Errorf("env file = %q, want the variable appended", content)
+	}
+
+	t.Setenv("GITHUB_ENV", "")
+	setupReadFlags(t, testRoot)
+	testRoot.SetArgs([]string{"read", "--path", "/app/password", "--format", "github"})
+	if err := testRoot.Execute(); err == nil || !strings.Contains(err.Error(), "requires --file or the GITHUB_ENV") {
+		t.Errorf("runRead() error = %v, want missing GITHUB_ENV error", err)
+	}
+
+	for _, args := range [][]string{
+		{"--changed-exit-code", "3"},
+		{"--on-change", "true"},
+		{"--signal", "HUP", "--pid", "1"},
+		{"--watch"},
+	} {
+		setupReadFlags(t, testRoot)
+		testRoot.SetArgs(append([]string{"read", "--path", "/app/password", "--format", "github"}, args...))
+		if err := testRoot.Execute(); err == nil || !strings.Contains(err.Error(), "can't be used with the github format") {
+			t.Errorf("runRead(%v) error = %v, want github format error", args, err)
+		}
+	}
+}
+
+func TestRunReadChanged(t *testing.T) {
+	rts := setupReadTest(t)
+	defer rts.cleanup()
+
+	origOsExit := osExit
+	defer func() {
+		osExit = origOsExit
+		readChangedExitCode, readOnChange = 0, ""
+	}()
+	var exitCode int
+	osExit = func(code int) { exitCode = code }
+
+	file := filepath.Join(rts.tmpDir, "app.env")
+	marker := filepath.Join(rts.tmpDir, "changed")
+	args := []string{"read", "--path", "/app/url", "--file", file, "--changed-exit-code", "3", "--on-change", "echo x >> " + marker}
+
+	for i, wantChanged := range []bool{true, false} {
+		exitCode = 0
+		testRoot := &cobra.Command{Use: "params2env"}
+		setupReadFlags(t, testRoot)
+		testRoot.SetArgs(args)
+
+		oldStdout := os.Stdout
+		devNull, _ := os.Open(os.DevNull)
+		os.Stdout = devNull
+		err := testRoot.Execute()
+		os.Stdout = oldStdout
+		_ = devNull.Close()
+		if err != nil {
+			t.Fatalf("run %d: runRead() error = %v", i, err)
+		}
+
+		wantCode := 0
+		if wantChanged {
+			wantCode = 3
+		}
+		if exitCode != wantCode {
+			t.Errorf("run %d: exit code = %d, want %d", i, exitCode, wantCode)
+		}
+	}
+
+	// The hook only ran on the first run
+	content, err := os.ReadFile(marker)
+	if err != nil {
+		t.Fatalf("Failed to read marker file: %v", err)
+	}
+	if string(content) != "x\n" {
+		t.Errorf("on-change ran %d times, want once", strings.Count(string(content), "x"))
+	}
+
+	testRoot := &cobra.Command{Use: "params2env"}
+	setupReadFlags(t, testRoot)
+	testRoot.SetArgs([]string{"read", "--path", "/app/url", "--changed-exit-code", "1"})
+	if err := testRoot.Execute(); err == nil || !strings.Contains(err.Error(), "invalid changed exit code") {
+		t.Errorf("runRead() error = %v, want invalid changed exit code", err)
+	}
+
+	setupReadFlags(t, testRoot)
+	testRoot.SetArgs([]string{"read", "--path", "/app/url", "--on-change", "true"})
+	if err := testRoot.Execute(); err == nil || !strings.Contains(err.Error(), "require a file") {
+		t.Errorf("runRead() error = %v, want missing file error", err)
+	}
+}
+
+func TestFormatEnvName(t *testing.T) {
+	defer func() { readEnvNaming, readStripPrefix, readPrefix, readUpper = "", "", "", true }()
+
+	tests := []struct {
+		name        string
+		path        string
+		envName     string
+		naming      string
+		stripPrefix string
+		prefix      string
+		want        string
+	}{
+		{name: "base", path: "/app/db/url", want: "URL"},
+		{name: "base_normalized", path: "/app/db-host.name", want: "DB_HOST_NAME"},
+		{name: "base_leading_digit", path: "/app/2fa-key", want: "_2FA_KEY"},
+		{name: "base_leading_digit_with_prefix", path: "/app/2fa-key", prefix: "APP", want: "APP_2FA_KEY"},
+		{name: "path", path: "/app/db-1/url", naming: config.EnvNamingPath, want: "APP_DB_1_URL"},
+		{name: "relative", path: "/app/prod/db/url", naming: config.EnvNamingRelative, stripPrefix: "/app/prod", want: "DB_URL"},
+		{name: "explicit_name_kept", path: "/app/db/url", envName: "db-url", naming: config.EnvNamingPath, want: "DB-URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readEnvNaming, readStripPrefix, readPrefix, readUpper = tt.naming, tt.stripPrefix, tt.prefix, true
+			if got := formatEnvName(tt.path, tt.envName, nil); got != tt.want {
+				t.Errorf("formatEnvName(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunReadEnvNaming(t *testing.T) {
+	rts := setupReadTest(t)
+	defer rts.cleanup()
+	defer func() { readEnvNaming, readStripPrefix = "", "" }()
+	configFile := filepath.Join(rts.tmpDir, ".params2env.yaml")
+
+	tests := []struct {
+		name   string
+		config string
+		args   []string
+		errMsg string
+	}{
+		{
+			name:   "duplicate_names",
+			config: "params:\n  - name: /app/db/url\n  - name: /cache/url\n",
+			errMsg: "parameters '/app/db/url' and '/cache/url' both map to the name 'URL'",
+		},
+		{
+			name:   "full_path_names",
+			config: "env_naming: path\nparams:\n  - name: /app/db/url\n  - name: /cache/url\n",
+		},
+		{
+			name:   "relative_names",
+			config: "params:\n  - name: /app/db/url\n  - name: /app/cache/url\n",
+			args:   []string{"--env-naming", "relative", "--strip-prefix", "/app"},
+		},
+		{
+			name:   "invalid_naming",
+			config: "params:\n  - name: /app/url\n",
+			args:   []string{"--env-naming", "flat"},
+			errMsg: "invalid env naming",
+		},
+		{
+			name:   "relative_without_prefix",
+			config: "params:\n  - name: /app/url\n",
+			args:   []string{"--env-naming", "relative"},
+			errMsg: "requires a strip prefix",
+		},
+		{
+			name:   "not_below_prefix",
+			config: "env_naming: relative\nstrip_prefix: /app\nparams:\n  - name: /app/url\n  - name: /other/url\n",
+			errMsg: "'/other/url' is not below the strip prefix '/app'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runReadOutputDir(t, configFile, tt.config, tt.args...)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("runRead() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("runRead() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
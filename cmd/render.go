@@ -0,0 +1,298 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+	"git.sr.ht/~wombelix/params2env/internal/config"
+	"git.sr.ht/~wombelix/params2env/internal/fileutil"
+	"git.sr.ht/~wombelix/params2env/internal/validation"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Command-line flags for the render command
+var (
+	// renderTemplate is the template file to render
+	renderTemplate string
+	// renderOut is the file to write, stdout if empty
+	renderOut string
+	// renderRegion is the AWS region to read the parameters from
+	renderRegion string
+	// renderRole is the AWS IAM role to assume
+	renderRole string
+)
+
+// renderCmd represents the render command
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render a template with parameter values",
+	Long: `Render a Go text/template with values from SSM Parameter Store.
+
+The template can use these functions:
+  ssm "/path"        Value of a parameter
+  ssmSecure "/path"  Value of a SecureString parameter, fails for other types
+  env "NAME"         Value of an environment variable
+  default "x" VALUE  VALUE, or "x" if VALUE is empty
+  base64 VALUE       VALUE encoded as base64
+  json VALUE         VALUE encoded as JSON
+  toYaml VALUE       VALUE encoded as YAML
+
+All parameters the template references are read in batches before it's
+rendered. Parameters that are only referenced depending on other values are
+read when they are needed. The output is written atomically with 0600
+permissions, or to stdout if --out isn't set.
+
+Examples:
+  # Render a config file
+  params2env render --template app.conf.tmpl --out app.conf
+
+  # A template line with a secret and a default
+  password = {{ ssmSecure "/myapp/db/password" }}
+  log_level = {{ env "LOG_LEVEL" | default "info" }}`,
+	PreRunE: validateRenderFlags,
+	RunE:    runRender,
+}
+
+// validateRenderFlags checks if all required flags are set and valid
+func validateRenderFlags(cmd *cobra.Command, args []string) error {
+	if renderTemplate == "" {
+		return fmt.Errorf("required flag \"template\" not set")
+	}
+
+	if err := validation.ValidateRegion(renderRegion); err != nil {
+		return err
+	}
+
+	if err := validation.ValidateRoleARN(renderRole); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// runRender executes the render command
+func runRender(cmd *cobra.Command, args []string) error {
+	text, err := os.ReadFile(renderTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Merge config with flags (flags take precedence)
+	mergeRenderConfig(cfg)
+
+	// Ensure region is set
+	if renderRegion == "" {
+		if renderRegion = os.Getenv("AWS_REGION"); renderRegion == "" {
+			return fmt.Errorf("AWS region must be specified via --region, config file, or AWS_REGION environment variable")
+		}
+	}
+
+	name := filepath.Base(renderTemplate)
+	paths, err := templateParameterPaths(name, string(text))
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := aws.NewClient(ctx, renderRegion, renderRole)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	params, err := client.GetParameters(ctx, paths)
+	if err != nil {
+		return fmt.Errorf("failed to read parameters from region '%s': %w", renderRegion, err)
+	}
+	lookup := func(path string) (aws.Parameter, error) {
+		if p, ok := params[path]; ok {
+			return p, nil
+		}
+		p, err := client.GetParameterInfo(ctx, path)
+		if err != nil {
+			return aws.Parameter{}, fmt.Errorf("failed to read parameter from region '%s': %w", renderRegion, err)
+		}
+		params[path] = *p
+		return *p, nil
+	}
+
+	output, err := executeTemplate(name, string(text), lookup)
+	if err != nil {
+		return err
+	}
+
+	if renderOut == "" {
+		fmt.Print(output)
+		return nil
+	}
+
+	// Ensure directory exists with secure permissions (0700 - owner access only)
+	dir := filepath.Dir(renderOut)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	if err := fileutil.WriteAtomic(renderOut, []byte(output), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", renderOut, err)
+	}
+
+	fmt.Printf("Successfully rendered '%s' with %d parameter(s) from region '%s' to '%s'\n",
+		renderTemplate, len(params), renderRegion, renderOut)
+	return nil
+}
+
+// mergeRenderConfig merges configuration from file with command line flags
+func mergeRenderConfig(cfg *config.Config) {
+	if cfg == nil {
+		return
+	}
+	if renderRegion == "" {
+		renderRegion = cfg.Region
+	}
+	if renderRole == "" {
+		renderRole = cfg.Role
+	}
+}
+
+// templateParameterPaths returns the sorted paths of all parameters a
+// template references. The template is executed with empty parameter
+// values, so paths that are only referenced depending on the value of
+// another parameter may be missing. Errors of that execution are ignored,
+// the real one reports them.
+//
+// Parameters:
+//   - name: The template name used in error messages
+//   - text: The template
+//
+// Returns:
+//   - The parameter paths
+//   - An error if the template can't be parsed
+func templateParameterPaths(name, text string) ([]string, error) {
+	seen := make(map[string]bool)
+	lookup := func(path string) (aws.Parameter, error) {
+		if validation.ValidateParameterPath(path) == nil {
+			seen[path] = true
+		}
+		// Passes the type check of ssmSecure
+		return aws.Parameter{Name: path, Type: aws.ParameterTypeSecureString}, nil
+	}
+
+	tmpl, err := parseTemplate(name, text, lookup)
+	if err != nil {
+		return nil, err
+	}
+	_ = tmpl.Execute(io.Discard, nil)
+
+	paths := make([]string, 0, len(seen))
+	for path := range seen {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// executeTemplate renders a template
+//
+// Parameters:
+//   - name: The template name used in error messages
+//   - text: The template
+//   - lookup: Returns the parameter of a path
+//
+// Returns:
+//   - The rendered template
+//   - An error if the template can't be parsed or rendered
+func executeTemplate(name, text string, lookup func(string) (aws.Parameter, error)) (string, error) {
+	tmpl, err := parseTemplate(name, text, lookup)
+	if err != nil {
+		return "", err
+	}
+
+	var output strings.Builder
+	if err := tmpl.Execute(&output, nil); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return output.String(), nil
+}
+
+// parseTemplate parses a template with the functions backed by lookup
+func parseTemplate(name, text string, lookup func(string) (aws.Parameter, error)) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(templateFuncs(lookup)).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// templateFuncs returns the functions available in templates
+func templateFuncs(lookup func(string) (aws.Parameter, error)) template.FuncMap {
+	get := func(path string) (aws.Parameter, error) {
+		if err := validation.ValidateParameterPath(path); err != nil {
+			return aws.Parameter{}, err
+		}
+		return lookup(path)
+	}
+
+	return template.FuncMap{
+		"ssm": func(path string) (string, error) {
+			p, err := get(path)
+			return p.Value, err
+		},
+		"ssmSecure": func(path string) (string, error) {
+			p, err := get(path)
+			if err != nil {
+				return "", err
+			}
+			if p.Type != aws.ParameterTypeSecureString {
+				return "", fmt.Errorf("parameter %s is of type %s, not %s", path, p.Type, aws.ParameterTypeSecureString)
+			}
+			return p.Value, nil
+		},
+		"env": os.Getenv,
+		"default": func(def, value any) any {
+			if value == nil {
+				return def
+			}
+			if v := reflect.ValueOf(value); v.IsZero() || (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.Len() == 0 {
+				return def
+			}
+			return value
+		},
+		"base64": func(value string) string {
+			return base64.StdEncoding.EncodeToString([]byte(value))
+		},
+		"json": func(value any) (string, error) {
+			data, err := json.Marshal(value)
+			return string(data), err
+		},
+		"toYaml": func(value any) (string, error) {
+			data, err := yaml.Marshal(value)
+			return strings.TrimSuffix(string(data), "\n"), err
+		},
+	}
+}
+
+func init() {
+	renderCmd.Flags().StringVar(&renderTemplate, "template", "", "Template file to render (required)")
+	renderCmd.Flags().StringVar(&renderOut, "out", "", "File to write (optional, default: stdout)")
+	renderCmd.Flags().StringVar(&renderRegion, "region", "", "AWS region (optional, default: from config or environment)")
+	renderCmd.Flags().StringVar(&renderRole, "role", "", "AWS role ARN to assume (optional)")
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+)
+
+// setupRenderFlags resets the render command flags for testing
+func setupRenderFlags() {
+	renderCmd.ResetFlags()
+	renderCmd.Flags().StringVar(&renderTemplate, "template", "", "Template file")
+	renderCmd.Flags().StringVar(&renderOut, "out", "", "Output file")
+	renderCmd.Flags().StringVar(&renderRegion, "region", "", "AWS region")
+	renderCmd.Flags().StringVar(&renderRole, "role", "", "AWS role ARN")
+	testRoot.AddCommand(renderCmd)
+}
+
+// testRenderStore returns a region store with parameters to render
+func testRenderStore() *regionStore {
+	return newRegionStore(map[string]map[string]aws.Parameter{
+		"eu-central-1": {
+			"/app/url":      {Name: "/app/url", Value: "https://example.com", Type: aws.ParameterTypeString, Version: 1},
+			"/app/password": {Name: "/app/password", Value: "s3cret", Type: aws.ParameterTypeSecureString, Version: 1},
+			"/app/hosts":    {Name: "/app/hosts", Value: "a,b", Type: aws.ParameterTypeStringList, Version: 1},
+		},
+	})
+}
+
+func TestRunRender(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+	t.Setenv("LOG_LEVEL", "")
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		errMsg   string
+	}{
+		{
+			name:     "values",
+			template: `url={{ ssm "/app/url" }} password={{ ssmSecure "/app/password" }}`,
+			want:     "url=https://example.com password=s3cret",
+		},
+		{
+			name:     "functions",
+			template: `{{ env "LOG_LEVEL" | default "info" }} {{ ssm "/app/password" | base64 }} {{ ssm "/app/url" | json }} {{ ssm "/app/hosts" | toYaml }}`,
+			want:     `info czNjcmV0 "https://example.com" a,b`,
+		},
+		{
+			name:     "conditional_reference",
+			template: `{{ if eq (ssm "/app/url") "https://example.com" }}{{ ssm "/app/hosts" }}{{ end }}`,
+			want:     "a,b",
+		},
+		{name: "not_secure", template: `{{ ssmSecure "/app/url" }}`, errMsg: "not SecureString"},
+		{name: "missing_parameter", template: `{{ ssm "/app/missing" }}`, errMsg: "parameter not found"},
+		{name: "invalid_path", template: `{{ ssm "app" }}`, errMsg: "failed to render template"},
+		{name: "invalid_template", template: `{{ ssm "/app/url" `, errMsg: "failed to parse template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testRenderStore().install()
+			dir := t.TempDir()
+			tmpl := filepath.Join(dir, "app.conf.tmpl")
+			if err := os.WriteFile(tmpl, []byte(tt.template), 0600); err != nil {
+				t.Fatalf("Failed to write template: %v", err)
+			}
+			out := filepath.Join(dir, "conf", "app.conf")
+
+			setupRenderFlags()
+			testRoot.SetArgs([]string{"render", "--template", tmpl, "--out", out, "--region", "eu-central-1"})
+			err := testRoot.Execute()
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("runRender() error = %v, want error containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runRender() error = %v", err)
+			}
+
+			content, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatalf("Failed to read output: %v", err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("runRender() wrote %q, want %q", content, tt.want)
+			}
+			info, err := os.Stat(out)
+			if err != nil {
+				t.Fatalf("Failed to stat output: %v", err)
+			}
+			if perm := info.Mode().Perm(); perm != 0600 {
+				t.Errorf("output permissions = %o, want 600", perm)
+			}
+		})
+	}
+}
+
+func TestTemplateParameterPaths(t *testing.T) {
+	text := `{{ ssm "/app/b" }}{{ ssmSecure "/app/a" }}{{ ssm "/app/b" | default "x" }}{{ ssm "invalid" }}`
+	got, err := templateParameterPaths("test", text)
+	if err != nil {
+		t.Fatalf("templateParameterPaths() error = %v", err)
+	}
+	if want := []string{"/app/a", "/app/b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("templateParameterPaths() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// errUnchanged is returned by a region operation that skipped the write
+// because the parameter already has the desired state
+var errUnchanged = errors.New("parameter unchanged")
+
+// regionResult holds the outcome of an operation in a single region
+type regionResult struct {
+	region string
+	err    error
+}
+
+// runInRegions executes fn for every region in parallel and waits until all
+// of them are finished. The results are returned in the order of regions,
+// a failure in one region doesn't stop the operation in the others.
+func runInRegions(regions []string, fn func(region string) error) []regionResult {
+	results := make([]regionResult, len(regions))
+
+	var wg sync.WaitGroup
+	for i, region := range regions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = regionResult{region: region, err: fn(region)}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// reportRegionResults prints the outcome for every replica region and returns
+// an error combining all failures, or nil if the operation succeeded everywhere.
+// The action is used in the messages, e.g. "created" or "deleted".
+func reportRegionResults(action, path string, results []regionResult) error {
+	var errs []error
+	for _, result := range results {
+		if errors.Is(result.err, errUnchanged) {
+			fmt.Printf("Parameter '%s' is unchanged in replica region '%s'\n", path, result.region)
+			continue
+		}
+		if result.err != nil {
+			fmt.Printf("Failed to %s parameter '%s' in replica region '%s': %v\n", action, path, result.region, result.err)
+			errs = append(errs, result.err)
+			continue
+		}
+		fmt.Printf("Successfully %s parameter '%s' in replica region '%s'\n", pastTense(action), path, result.region)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to %s parameter '%s' in %d of %d replica region(s): %w",
+			action, path, len(errs), len(results), errors.Join(errs...))
+	}
+	return nil
+}
+
+// pastTense returns the past tense of the actions used in region reports
+func pastTense(action string) string {
+	switch action {
+	case "create":
+		return "created"
+	case "modify":
+		return "modified"
+	case "delete":
+		return "deleted"
+	}
+	return action + "ed"
+}
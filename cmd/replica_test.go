@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRunInRegions(t *testing.T) {
+	regions := []string{"eu-west-1", "us-east-1", "ap-southeast-1"}
+
+	results := runInRegions(regions, func(region string) error {
+		if region == "us-east-1" {
+			return fmt.Errorf("boom")
+		}
+		return nil
+	})
+
+	if len(results) != len(regions) {
+		t.Fatalf("runInRegions() returned %d results, want %d", len(results), len(regions))
+	}
+	for i, result := range results {
+		if result.region != regions[i] {
+			t.Errorf("runInRegions() result %d region = %s, want %s", i, result.region, regions[i])
+		}
+		if (result.err != nil) != (result.region == "us-east-1") {
+			t.Errorf("runInRegions() result for %s error = %v", result.region, result.err)
+		}
+	}
+}
+
+func TestReportRegionResults(t *testing.T) {
+	tests := []struct {
+		name        string
+		results     []regionResult
+		wantErr     bool
+		errContains []string
+	}{
+		{
+			name: "all_succeeded",
+			results: []regionResult{
+				{region: "eu-west-1"},
+				{region: "us-east-1"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "partial_failure",
+			results: []regionResult{
+				{region: "eu-west-1"},
+				{region: "us-east-1", err: fmt.Errorf("access denied in us-east-1")},
+				{region: "ap-southeast-1", err: fmt.Errorf("throttled in ap-southeast-1")},
+			},
+			wantErr:     true,
+			errContains: []string{"2 of 3 replica region(s)", "access denied in us-east-1", "throttled in ap-southeast-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := reportRegionResults("create", "/test/param", tt.results)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("reportRegionResults() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, part := range tt.errContains {
+				if !strings.Contains(err.Error(), part) {
+					t.Errorf("reportRegionResults() error = %v, should contain %q", err, part)
+				}
+			}
+		})
+	}
+}
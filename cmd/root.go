@@ -12,6 +12,7 @@
 // Global flags supported by all commands include:
 //   - --loglevel: Set logging verbosity (debug, info, warn, error)
 //   - --version: Display version information
+//   - --dry-run: Print the planned changes without writing them
 //   - --help: Show help and usage information
 package cmd
 
@@ -32,6 +33,7 @@ var (
 	// Command-line flags
 	logLevel    string
 	showVersion bool
+	dryRun      bool
 
 	// rootCmd represents the base command when called without any subcommands.
 	// It provides global flags and displays help information by default.
@@ -40,7 +42,8 @@ var (
 		Short: "A tool to manage AWS SSM Parameter Store entries",
 		Long: `params2env is a command-line tool for managing AWS SSM Parameter Store entries.
 It allows you to read, create, and modify parameters, with support for replication
-across regions and secure string parameters using KMS keys.`,
+across regions, drift detection between replicas, copying parameter trees
+between regions or accounts and secure string parameters using KMS keys.`,
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -62,6 +65,7 @@ across regions and secure string parameters using KMS keys.`,
 func init() {
 	rootCmd.PersistentFlags().StringVar(&logLevel, "loglevel", "info", "Log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().BoolVar(&showVersion, "version", false, "Show version information")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the planned changes without writing them")
 
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		// Initialize logger with the specified log level
@@ -74,6 +78,14 @@ func init() {
 	rootCmd.AddCommand(createCmd)
 	rootCmd.AddCommand(modifyCmd)
 	rootCmd.AddCommand(deleteCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(importEnvCmd)
+	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(renderCmd)
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -93,6 +105,7 @@ A tool to manage AWS SSM Parameter Store entries.
 Global options:
   --loglevel string   Log level (debug, info, warn, error) (default "info")
   --version           Show version information
+  --dry-run           Print the planned changes without writing them
   --help             Show this help message
 
 Subcommands:
@@ -101,27 +114,144 @@ Subcommands:
       --path string     Parameter path (required)
       --region string   AWS region (optional, default: from AWS config or environment)
       --role string     AWS role ARN to assume (optional)
+      --env-naming string Naming of env vars derived from paths: base, path, relative (default: base)
+      --strip-prefix string Path prefix removed by the relative env naming (optional)
+      --list-format string Rendering of StringList parameters: join, split (default: join)
+      --list-separator string Separator for joined StringList items (default: ,)
+      --format string   Output format: shell, docker, systemd, k8s, github, gitlab-dotenv (default: shell)
+      --k8s-name string Name of the Kubernetes Secret and ConfigMap (default: params2env)
+      --k8s-namespace string Namespace of the Kubernetes Secret and ConfigMap (optional)
+      --output-dir string Directory to write one file per parameter to (optional)
+      --file-naming string Naming of the files in the output directory: env, path (default: env)
+      --merge           Update the managed block of an existing file instead of replacing it
+      --changed-exit-code int Exit code if the output changed (optional)
+      --on-change string Shell command to run if the output changed (optional)
+      --signal string   Signal to send to --pid or --pid-file if the output changed (optional)
+      --pid int         Process that receives --signal (optional)
+      --pid-file string File with the process ID that receives --signal (optional)
+      --watch           Keep running and read the parameters every --interval
+      --interval duration Time between two reads in watch mode (default: 5m)
+      --file-mode string Octal permissions of written files (default: 0600)
+      --file-owner string User name or ID that owns written files (optional)
+      --file-group string Group name or ID of written files (optional)
 
   create  Create a new parameter in SSM Parameter Store
     Options:
       --path string        Parameter path (required)
-      --value string       Parameter value (required)
-      --type string        Parameter type (String or SecureString) (default: String)
+      --value string       Parameter value (required unless read from a file, stdin or the terminal)
+      --value-file string  Read the parameter value from a file (optional)
+      --value-stdin bool   Read the parameter value from stdin (optional)
+      --trim bool          Remove surrounding whitespace from a file or stdin value (optional, default: false)
+      --generate bool      Generate a random value, default type SecureString (optional, default: false)
+      --encoding string    Generated value encoding: chars, alphanumeric, hex, base64, passphrase (default: chars)
+      --length int         Generated characters, bytes for hex/base64 or words (default: 32 or 6 words)
+      --classes strings    Character classes: lower, upper, digits, symbols (default: all)
+      --exclude-chars string Characters the generated value must not contain (optional)
+      --print bool         Print the generated value after it was stored (optional, default: false)
+      --type string        Parameter type (String, StringList or SecureString) (default: String)
       --description string Parameter description (optional)
       --kms string         KMS key ID for SecureString parameters (optional)
       --region string      AWS region (optional, default: from AWS config or environment)
       --role string        AWS role ARN to assume (optional)
-      --replica string     Region to replicate the parameter to (optional)
+      --replica strings    Regions to replicate the parameter to (optional)
+      --replica-kms map    KMS key ID per replica region as region=key (optional)
       --overwrite bool     Overwrite existing parameter (optional, default: false)
+      --atomic bool        Roll back all regions if any region fails (optional, default: false)
+      --force bool         Overwrite the parameter even if it is unchanged (optional, default: false)
 
   modify  Modify an existing parameter in SSM Parameter Store
     Options:
       --path string        Parameter path (required)
-      --value string       New parameter value (required)
+      --value string       New parameter value (required unless read from a file, stdin or the terminal)
+      --value-file string  Read the new parameter value from a file (optional)
+      --value-stdin bool   Read the new parameter value from stdin (optional)
+      --trim bool          Remove surrounding whitespace from a file or stdin value (optional, default: false)
       --description string New parameter description (optional)
       --region string      AWS region (optional, default: from AWS config or environment)
       --role string        AWS role ARN to assume (optional)
-      --replica string     Region to replicate the parameter to (optional)
+      --replica strings    Regions to replicate the parameter to (optional)
+      --atomic bool        Roll back all regions if any region fails (optional, default: false)
+      --type string        New parameter type (optional, default: keep current type)
+      --kms string         New KMS key ID, implies --type SecureString (optional)
+      --replica-kms map    KMS key ID per replica region as region=key (optional)
+      --force bool         Write the parameter even if it is unchanged (optional, default: false)
+      --expect-version int Only modify if the parameter has this version (optional)
+      --expect-value-hash string Only modify if the current value has this SHA-256 hash (optional)
+
+  verify  Compare parameters between the primary and replica regions
+    Options:
+      --path string        Parameter path (required unless --prefix is set)
+      --prefix string      Parameter path prefix to verify recursively
+      --region string      AWS region (optional, default: from AWS config or environment)
+      --role string        AWS role ARN to assume (optional)
+      --replica strings    Replica regions to compare (optional, default: from config)
+      --fix bool           Write the primary state to drifted replica regions (optional)
+
+  sync    Copy a parameter subtree between regions or accounts
+    Options:
+      --prefix string      Parameter path prefix to copy recursively (required)
+      --from-region string Source AWS region (optional, default: from AWS config or environment)
+      --to-region string   Destination AWS region (required)
+      --from-role string   AWS role ARN to assume in the source (optional)
+      --to-role string     AWS role ARN to assume in the destination (optional, default: source role)
+      --prune bool         Delete destination parameters that don't exist in the source (optional)
+      --overwrite bool     Update destination parameters that differ from the source (optional)
+
+  apply   Apply parameter definitions from a manifest file
+    Options:
+      -f, --file string    Manifest file with the parameter definitions (required)
+      --region string      AWS region (optional, default: from manifest, config or environment)
+      --role string        AWS role ARN to assume (optional, default: from manifest or config)
+      --auto-approve bool  Apply the plan without asking for confirmation (optional, default: false)
+
+  export  Export a parameter subtree to a backup file
+    Options:
+      --prefix string      Parameter path prefix to export recursively (required)
+      --out string         Backup file to write (required)
+      --format string      Backup format, json or yaml (optional, default: from file extension)
+      --region string      AWS region (optional, default: from AWS config or environment)
+      --role string        AWS role ARN to assume (optional)
+      --encrypt bool       Encrypt the backup with a passphrase (optional, default: false)
+
+  import  Restore parameters from a backup file
+    Options:
+      --in string          Backup file to restore (required)
+      --region string      AWS region (optional, default: from config, environment or backup)
+      --role string        AWS role ARN to assume (optional)
+      --on-conflict string Policy for existing parameters that differ: skip, overwrite, fail (default: fail)
+
+  import-env  Import the variables of a .env file as parameters
+    Options:
+      --file string        The .env file to import (required)
+      --prefix string      Parameter path prefix to import below (required)
+      --naming string      Naming rule for path segments: lower, kebab, path, keep (default: lower)
+      --secure-pattern strings Variable name patterns stored as SecureString (default: *PASSWORD*, *SECRET*, *TOKEN*, ...)
+      --kms string         KMS key ID for SecureString parameters (optional, default: from config)
+      --region string      AWS region (optional, default: from AWS config or environment)
+      --role string        AWS role ARN to assume (optional)
+      --on-conflict string Policy for existing parameters that differ: skip, overwrite, fail (default: fail)
+
+  run     Run a command with parameters as environment variables
+    Usage: params2env run [options] -- command [args...]
+    Options:
+      --path string        Parameter path (required if no parameters defined in config)
+      --region string      AWS region (optional, default: from AWS config or environment)
+      --role string        AWS role ARN to assume (optional)
+      --env-naming string  Naming of env vars derived from paths: base, path, relative (default: base)
+      --strip-prefix string Path prefix removed by the relative env naming (optional)
+      --watch bool         Poll the parameters and restart the command on changes (optional, default: false)
+      --interval duration  Time between two polls in watch mode (default: 5m)
+      --signal string      Signal to send on changes instead of restarting (optional)
+      --stop-signal string Signal to stop the command before a restart (default: TERM)
+      --stop-timeout duration Time the command has to stop before it's killed (default: 10s)
+      --min-restart-interval duration Minimum time between two restarts (default: 1m)
+
+  render  Render a template with parameter values
+    Options:
+      --template string    Template file to render (required)
+      --out string         File to write (optional, default: stdout)
+      --region string      AWS region (optional, default: from AWS config or environment)
+      --role string        AWS role ARN to assume (optional)
 
 For more information, visit: https://git.sr.ht/~wombelix/params2env
 `)
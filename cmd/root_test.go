@@ -46,6 +46,9 @@ func setupExecuteTest(t *testing.T) func() {
 		DeleteParamFunc: func(ctx context.Context, input *ssm.DeleteParameterInput, opts ...func(*ssm.Options)) (*ssm.DeleteParameterOutput, error) {
 			return &ssm.DeleteParameterOutput{}, nil
 		},
+		DescribeFunc: func(ctx context.Context, input *ssm.DescribeParametersInput, opts ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error) {
+			return &ssm.DescribeParametersOutput{}, nil
+		},
 	}
 
 	// Save original NewClient and restore after tests
@@ -62,11 +65,14 @@ func setupExecuteTest(t *testing.T) func() {
 	}
 }
 
-func setupRootCmd() {
+func setupRootCmd(t *testing.T) {
 	rootCmd.ResetFlags()
 	rootCmd.ResetCommands()
 	rootCmd.PersistentFlags().StringVar(&logLevel, "loglevel", "info", "Log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().BoolVar(&showVersion, "version", false, "Show version information")
+	setupCreateFlags()
+	setupModifyFlags()
+	setupDeleteFlags(t)
 	rootCmd.AddCommand(readCmd)
 	rootCmd.AddCommand(createCmd)
 	rootCmd.AddCommand(modifyCmd)
@@ -76,7 +82,7 @@ func setupRootCmd() {
 func TestExecuteVersion(t *testing.T) {
 	cleanup := setupExecuteTest(t)
 	defer cleanup()
-	setupRootCmd()
+	setupRootCmd(t)
 	rootCmd.SetArgs([]string{"--version"})
 	if err := Execute(); err != nil {
 		t.Errorf("Execute() error = %v, wantErr false", err)
@@ -86,7 +92,7 @@ func TestExecuteVersion(t *testing.T) {
 func TestExecuteHelp(t *testing.T) {
 	cleanup := setupExecuteTest(t)
 	defer cleanup()
-	setupRootCmd()
+	setupRootCmd(t)
 	rootCmd.SetArgs([]string{"--help"})
 	if err := Execute(); err != nil {
 		t.Errorf("Execute() error = %v, wantErr false", err)
@@ -112,7 +118,7 @@ func TestExecuteSubcommands(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			setupRootCmd()
+			setupRootCmd(t)
 			rootCmd.SetArgs(tt.args)
 			err := Execute()
 			if (err != nil) != tt.wantErr {
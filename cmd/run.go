@@ -0,0 +1,264 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"git.sr.ht/~wombelix/params2env/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// Command-line flags for the run command
+var (
+	// runWatch polls the parameters and restarts or signals the child on
+	// changes
+	runWatch bool
+	// runInterval is the time between two polls
+	runInterval time.Duration
+	// runSignal is sent to the child on changes instead of restarting it
+	runSignal string
+	// runStopSignal asks the child to stop before a restart
+	runStopSignal string
+	// runStopTimeout is the time the child has to stop before it's killed
+	runStopTimeout time.Duration
+	// runMinRestartInterval is the minimum time between two starts of the
+	// child, later changes are applied once it passed
+	runMinRestartInterval time.Duration
+)
+
+// runCmd represents the run command
+var runCmd = &cobra.Command{
+	Use:   "run [flags] -- command [args...]",
+	Short: "Run a command with parameters as environment variables",
+	Long: `Run a command with parameters from SSM Parameter Store as environment variables.
+
+The parameters are selected like with the read command, by --path or the
+params of the config file. They are added to the environment of the command,
+the exit code of the command is returned.
+
+With --watch the parameters are read again every --interval. If a value
+changed, the command is restarted with the new environment: it receives
+--stop-signal and is killed if it doesn't exit within --stop-timeout. With
+--signal the command is signaled instead, e.g. to reload a config file. At
+most one restart happens per --min-restart-interval.
+
+Signals received by params2env are forwarded to the command. As PID 1 in a
+container, params2env also reaps orphaned processes.
+
+Examples:
+  # Run a command with a single parameter
+  params2env run --path /myapp/db/password --env DB_PASSWORD -- ./myapp
+
+  # Run a worker with the parameters from the config file and restart it
+  # when a secret rotates
+  params2env run --watch --interval 5m -- ./worker --queue jobs
+
+  # Send SIGHUP instead of restarting
+  params2env run --watch --signal HUP -- nginx -g "daemon off;"`,
+	Args:    cobra.MinimumNArgs(1),
+	PreRunE: validateRunFlags,
+	RunE:    runRun,
+}
+
+// validateRunFlags checks if all required flags are set and valid
+func validateRunFlags(cmd *cobra.Command, args []string) error {
+	if err := validateParameterFlags(); err != nil {
+		return err
+	}
+
+	if runWatch && runInterval <= 0 {
+		return fmt.Errorf("invalid interval: %s (must be positive)", runInterval)
+	}
+	if runSignal != "" {
+		if _, err := parseSignal(runSignal); err != nil {
+			return err
+		}
+	}
+	if cmd.Flags().Changed("stop-signal") {
+		if _, err := parseSignal(runStopSignal); err != nil {
+			return err
+		}
+	}
+	if runStopTimeout <= 0 {
+		return fmt.Errorf("invalid stop timeout: %s (must be positive)", runStopTimeout)
+	}
+	if runMinRestartInterval < 0 {
+		return fmt.Errorf("invalid minimum restart interval: %s (must not be negative)", runMinRestartInterval)
+	}
+
+	return nil
+}
+
+// runRun executes the run command
+func runRun(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	resolve := func() ([]envVar, error) { return resolveParameters(cfg) }
+	vars, err := resolve()
+	if err != nil {
+		return err
+	}
+
+	code, err := supervise(args, vars, resolve)
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		osExit(code)
+	}
+	return nil
+}
+
+// childEnv returns the environment of the current process with the
+// variables added, they replace existing variables of the same name
+func childEnv(vars []envVar) []string {
+	env := slices.DeleteFunc(os.Environ(), func(kv string) bool {
+		name, _, _ := strings.Cut(kv, "=")
+		return slices.ContainsFunc(vars, func(v envVar) bool { return v.name == name })
+	})
+	for _, v := range vars {
+		env = append(env, v.name+"="+v.value)
+	}
+	return env
+}
+
+// supervise runs a command until it exits. With runWatch the parameters are
+// polled and the command is restarted or signaled if they change.
+//
+// Parameters:
+//   - argv: The command and its arguments
+//   - vars: The variables of the first start
+//   - resolve: Reads the current variables
+//
+// Returns:
+//   - The exit code of the command
+//   - An error if the command can't be started
+func supervise(argv []string, vars []envVar, resolve func() ([]envVar, error)) (int, error) {
+	sigs := make(chan os.Signal, len(forwardedSignals))
+	signal.Notify(sigs, forwardedSignals...)
+	defer signal.Stop(sigs)
+
+	var child *exec.Cmd
+	var exited <-chan int
+	var lastStart time.Time
+	start := func() error {
+		child = exec.Command(argv[0], argv[1:]...)
+		child.Env = childEnv(vars)
+		child.Stdin, child.Stdout, child.Stderr = os.Stdin, os.Stdout, os.Stderr
+		var err error
+		if exited, err = startChild(child); err != nil {
+			return fmt.Errorf("failed to start %s: %w", argv[0], err)
+		}
+		lastStart = time.Now()
+		return nil
+	}
+	if err := start(); err != nil {
+		return 0, err
+	}
+
+	// Timers that aren't active are nil channels, which never fire
+	var poll, restart, kill <-chan time.Time
+	if runWatch {
+		poll = time.After(withJitter(runInterval))
+	}
+	var backoff time.Duration
+	restarting, stopping := false, false
+
+	stop := func() {
+		restarting = true
+		if err := stopChild(child.Process, runStopSignal); err != nil {
+			slog.Error("Failed to stop command", "command", argv[0], "error", err)
+		}
+		kill = time.After(runStopTimeout)
+	}
+
+	for {
+		select {
+		case code := <-exited:
+			kill = nil
+			if !restarting || stopping {
+				return code, nil
+			}
+			restarting = false
+			if err := start(); err != nil {
+				return 0, err
+			}
+			fmt.Printf("Restarted %s with changed parameters\n", argv[0])
+
+		case sig := <-sigs:
+			_ = child.Process.Signal(sig)
+			if sig == syscall.SIGTERM || sig == os.Interrupt {
+				stopping = true
+				poll, restart = nil, nil
+			}
+
+		case <-poll:
+			newVars, err := resolve()
+			if err != nil {
+				backoff = nextBackoff(backoff, runInterval)
+				slog.Error("Failed to read parameters", "error", err, "retry_in", backoff)
+				poll = time.After(backoff)
+				continue
+			}
+			backoff = 0
+			poll = time.After(withJitter(runInterval))
+			if slices.Equal(newVars, vars) {
+				continue
+			}
+			vars = newVars
+
+			if runSignal != "" {
+				sig, _ := parseSignal(runSignal)
+				if err := child.Process.Signal(sig); err != nil {
+					slog.Error("Failed to signal command", "command", argv[0], "error", err)
+					continue
+				}
+				fmt.Printf("Parameters changed, sent %s to %s\n", runSignal, argv[0])
+				continue
+			}
+			// A pending restart picks up the latest values
+			if restarting || restart != nil {
+				continue
+			}
+			if wait := runMinRestartInterval - time.Since(lastStart); wait > 0 {
+				fmt.Printf("Parameters changed, restarting %s in %s\n", argv[0], wait.Round(time.Second))
+				restart = time.After(wait)
+				continue
+			}
+			fmt.Printf("Parameters changed, restarting %s\n", argv[0])
+			stop()
+
+		case <-restart:
+			restart = nil
+			stop()
+
+		case <-kill:
+			kill = nil
+			_ = child.Process.Kill()
+		}
+	}
+}
+
+func init() {
+	addParameterFlags(runCmd)
+	runCmd.Flags().BoolVar(&runWatch, "watch", false, "Poll the parameters and restart the command on changes")
+	runCmd.Flags().DurationVar(&runInterval, "interval", 5*time.Minute, "Time between two polls in watch mode")
+	runCmd.Flags().StringVar(&runSignal, "signal", "", "Signal to send on changes instead of restarting (optional)")
+	runCmd.Flags().StringVar(&runStopSignal, "stop-signal", "TERM", "Signal to stop the command before a restart")
+	runCmd.Flags().DurationVar(&runStopTimeout, "stop-timeout", 10*time.Second, "Time the command has to stop before it's killed")
+	runCmd.Flags().DurationVar(&runMinRestartInterval, "min-restart-interval", time.Minute, "Minimum time between two restarts")
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// setupRunFlags resets the run command flags for testing
+func setupRunFlags(testRoot *cobra.Command) {
+	runCmd.ResetFlags()
+	addParameterFlags(runCmd)
+	runCmd.Flags().BoolVar(&runWatch, "watch", false, "Watch")
+	runCmd.Flags().DurationVar(&runInterval, "interval", 5*time.Minute, "Interval")
+	runCmd.Flags().StringVar(&runSignal, "signal", "", "Signal")
+	runCmd.Flags().StringVar(&runStopSignal, "stop-signal", "TERM", "Stop signal")
+	runCmd.Flags().DurationVar(&runStopTimeout, "stop-timeout", 10*time.Second, "Stop timeout")
+	runCmd.Flags().DurationVar(&runMinRestartInterval, "min-restart-interval", time.Minute, "Minimum restart interval")
+	testRoot.AddCommand(runCmd)
+}
+
+// resetRunFlags restores the defaults of the run command flags
+func resetRunFlags() {
+	setupRunFlags(&cobra.Command{})
+}
+
+func TestRunRun(t *testing.T) {
+	rts := setupReadTest(t)
+	defer rts.cleanup()
+	defer resetRunFlags()
+
+	origOsExit := osExit
+	defer func() { osExit = origOsExit }()
+	var exitCode int
+	osExit = func(code int) { exitCode = code }
+
+	out := filepath.Join(rts.tmpDir, "out")
+	t.Setenv("URL", "from-environment")
+	t.Setenv("OTHER", "kept")
+
+	testRoot := &cobra.Command{Use: "params2env"}
+	setupRunFlags(testRoot)
+	testRoot.SetArgs([]string{"run", "--path", "/app/url", "--", "sh", "-c", `echo "$URL $OTHER" > "$0"; exit 3`, out})
+	if err := testRoot.Execute(); err != nil {
+		t.Fatalf("runRun() error = %v", err)
+	}
+
+	if exitCode != 3 {
+		t.Errorf("exit code = %d, want 3", exitCode)
+	}
+	content, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if string(content) != "test-value kept\n" {
+		t.Errorf("command saw %q, want the parameter value and the kept environment", content)
+	}
+}
+
+func TestRunRunInvalid(t *testing.T) {
+	rts := setupReadTest(t)
+	defer rts.cleanup()
+	defer resetRunFlags()
+
+	tests := []struct {
+		name   string
+		args   []string
+		errMsg string
+	}{
+		{name: "missing_command", args: []string{"--path", "/app/url"}, errMsg: "requires at least 1 arg"},
+		{name: "invalid_signal", args: []string{"--path", "/app/url", "--signal", "NOPE", "--", "true"}, errMsg: "invalid signal"},
+		{name: "invalid_interval", args: []string{"--path", "/app/url", "--watch", "--interval", "0s", "--", "true"}, errMsg: "invalid interval"},
+		{name: "invalid_stop_timeout", args: []string{"--path", "/app/url", "--stop-timeout", "0s", "--", "true"}, errMsg: "invalid stop timeout"},
+		{name: "missing_command_binary", args: []string{"--path", "/app/url", "--", "params2env-no-such-command"}, errMsg: "failed to start"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testRoot := &cobra.Command{Use: "params2env"}
+			setupRunFlags(testRoot)
+			testRoot.SetArgs(append([]string{"run"}, tt.args...))
+			if err := testRoot.Execute(); err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("runRun() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+// resolveSequence returns a resolve function that returns the values one
+// after the other and then the last value forever
+func resolveSequence(values ...string) func() ([]envVar, error) {
+	calls := 0
+	return func() ([]envVar, error) {
+		v := values[min(calls, len(values)-1)]
+		calls++
+		return []envVar{{name: "VAL", value: v}}, nil
+	}
+}
+
+func TestSuperviseRestart(t *testing.T) {
+	defer resetRunFlags()
+	runWatch, runInterval, runMinRestartInterval, runStopTimeout = true, 10*time.Millisecond, 0, 5*time.Second
+
+	out := filepath.Join(t.TempDir(), "out")
+	// The first child waits to be stopped, the restarted child exits
+	script := `echo "$VAL" >> "$0"; [ "$VAL" = b ] && exit 4; exec sleep 30`
+	code, err := supervise([]string{"sh", "-c", script, out}, []envVar{{name: "VAL", value: "a"}}, resolveSequence("a", "b"))
+	if err != nil {
+		t.Fatalf("supervise() error = %v", err)
+	}
+
+	if code != 4 {
+		t.Errorf("supervise() = %d, want exit code 4 of the restarted command", code)
+	}
+	content, _ := os.ReadFile(out)
+	if string(content) != "a\nb\n" {
+		t.Errorf("command output = %q, want a start with each value", content)
+	}
+}
+
+func TestSuperviseSignal(t *testing.T) {
+	defer resetRunFlags()
+	runWatch, runInterval, runSignal = true, 10*time.Millisecond, "HUP"
+
+	out := filepath.Join(t.TempDir(), "out")
+	// Exits on SIGHUP, so the test ends after the first change
+	script := `trap 'echo "hup $VAL" >> "$0"; exit 0' HUP; echo "start $VAL" >> "$0"; while :; do sleep 0.01; done`
+	code, err := supervise([]string{"sh", "-c", script, out}, []envVar{{name: "VAL", value: "a"}}, resolveSequence("a", "a", "b"))
+	if err != nil {
+		t.Fatalf("supervise() error = %v", err)
+	}
+
+	if code != 0 {
+		t.Errorf("supervise() = %d, want 0", code)
+	}
+	// The environment of a running process can't change
+	content, _ := os.ReadFile(out)
+	if string(content) != "start a\nhup a\n" {
+		t.Errorf("command output = %q, want a single start and the signal", content)
+	}
+}
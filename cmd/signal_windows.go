@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package cmd
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// parseSignal fails on Windows, processes can't be signaled there
+func parseSignal(name string) (syscall.Signal, error) {
+	return 0, fmt.Errorf("invalid signal: %s (signals are not supported on Windows)", name)
+}
@@ -0,0 +1,374 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+	"git.sr.ht/~wombelix/params2env/internal/config"
+	"git.sr.ht/~wombelix/params2env/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+// Command-line flags for the sync command
+var (
+	// syncPrefix is the path of the parameter subtree to copy
+	syncPrefix string
+	// syncFromRegion is the AWS region to copy the parameters from
+	syncFromRegion string
+	// syncToRegion is the AWS region to copy the parameters to
+	syncToRegion string
+	// syncFromRole is the AWS IAM role to assume in the source
+	syncFromRole string
+	// syncToRole is the AWS IAM role to assume in the destination
+	syncToRole string
+	// syncPrune deletes destination parameters that don't exist in the source
+	syncPrune bool
+	// syncOverwrite updates destination parameters that differ from the source
+	syncOverwrite bool
+)
+
+// syncKMSKeys maps a destination region to the KMS key ID used for
+// SecureString parameters, taken from the kms_keys configuration setting
+var syncKMSKeys map[string]string
+
+// Actions of a sync plan
+const (
+	syncActionCreate    = "create"
+	syncActionUpdate    = "update"
+	syncActionUnchanged = "unchanged"
+	syncActionSkip      = "skip"
+	syncActionDelete    = "delete"
+	syncActionOrphaned  = "orphaned"
+)
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Copy a parameter subtree between regions or accounts",
+	Long: `Copy a parameter subtree between regions or accounts.
+
+All parameters below the prefix are copied from the source to the destination,
+preserving type, tier, description and tags. SecureString parameters are
+encrypted with the KMS key configured for the destination region in kms_keys,
+otherwise a KMS key ARN is translated to the destination region and aliases
+are used unchanged. A key ARN belongs to the source account, so with a
+different destination role it requires a kms_keys entry for the destination
+region.
+
+Parameters that already exist in the destination and differ from the source
+are only updated with --overwrite. Tags aren't compared, and a description
+can't be removed from a destination parameter. Parameters that only exist in
+the destination are only deleted with --prune. With --dry-run, the plan is
+printed without changing the destination.
+
+Examples:
+  # Copy a subtree to a new region
+  params2env sync --prefix /myapp --from-region eu-central-1 --to-region eu-west-1
+
+  # Show what would change in another account
+  params2env sync --prefix /myapp --to-region eu-central-1 \
+    --to-role arn:aws:iam::111122223333:role/params --dry-run
+
+  # Make the destination an exact copy of the source
+  params2env sync --prefix /myapp --to-region eu-west-1 --overwrite --prune`,
+	PreRunE: validateSyncFlags,
+	RunE:    runSync,
+}
+
+// syncItem is a single entry of a sync plan
+type syncItem struct {
+	// name is the full path of the parameter
+	name string
+	// action is one of the syncAction constants
+	action string
+	// reasons lists the detected differences for updated or skipped parameters
+	reasons []string
+	// source is the source parameter, empty for destination-only parameters
+	source aws.Parameter
+}
+
+// validateSyncFlags checks if all required flags are set and valid
+func validateSyncFlags(cmd *cobra.Command, args []string) error {
+	if syncPrefix == "" {
+		return fmt.Errorf("required flag \"prefix\" not set")
+	}
+	if err := validation.ValidateParameterPath(syncPrefix); err != nil {
+		return err
+	}
+
+	if syncToRegion == "" {
+		return fmt.Errorf("required flag \"to-region\" not set")
+	}
+	if err := validation.ValidateRegion(syncToRegion); err != nil {
+		return fmt.Errorf("invalid destination region: %w", err)
+	}
+	if err := validation.ValidateRegion(syncFromRegion); err != nil {
+		return fmt.Errorf("invalid source region: %w", err)
+	}
+
+	if err := validation.ValidateRoleARN(syncFromRole); err != nil {
+		return fmt.Errorf("invalid source role: %w", err)
+	}
+	if err := validation.ValidateRoleARN(syncToRole); err != nil {
+		return fmt.Errorf("invalid destination role: %w", err)
+	}
+
+	return nil
+}
+
+// runSync executes the sync command
+func runSync(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Merge config with flags (flags take precedence)
+	mergeSyncConfig(cfg)
+
+	// Ensure source region is set
+	if syncFromRegion == "" {
+		if syncFromRegion = os.Getenv("AWS_REGION"); syncFromRegion == "" {
+			return fmt.Errorf("source region must be specified via --from-region, config file, or AWS_REGION environment variable")
+		}
+	}
+
+	if syncFromRegion == syncToRegion && syncFromRole == syncToRole {
+		return fmt.Errorf("source and destination must differ in region or role")
+	}
+
+	ctx := context.Background()
+	srcClient, err := aws.NewClient(ctx, syncFromRegion, syncFromRole)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client for source region: %w", err)
+	}
+	dstClient, err := aws.NewClient(ctx, syncToRegion, syncToRole)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client for destination region: %w", err)
+	}
+
+	source, err := listSyncParameters(ctx, srcClient)
+	if err != nil {
+		return fmt.Errorf("failed to read parameters in source region '%s': %w", syncFromRegion, err)
+	}
+	dest, err := listSyncParameters(ctx, dstClient)
+	if err != nil {
+		return fmt.Errorf("failed to read parameters in destination region '%s': %w", syncToRegion, err)
+	}
+
+	plan := planSync(source, dest, syncOverwrite, syncPrune)
+
+	// Fail before anything is written if a KMS key can't be used
+	for _, item := range plan {
+		if item.action != syncActionCreate && item.action != syncActionUpdate {
+			continue
+		}
+		if _, err := syncKMSKeyID(item.source); err != nil {
+			return fmt.Errorf("failed to sync '%s': %w", item.name, err)
+		}
+	}
+
+	if dryRun {
+		fmt.Printf("Plan to sync '%s' from region '%s' to region '%s':\n", syncPrefix, syncFromRegion, syncToRegion)
+		for _, item := range plan {
+			printSyncItem(item, "")
+		}
+		printSyncSummary(plan)
+		return nil
+	}
+
+	fmt.Printf("Syncing '%s' from region '%s' to region '%s':\n", syncPrefix, syncFromRegion, syncToRegion)
+	var errs []error
+	for _, item := range plan {
+		if err := applySyncItem(ctx, srcClient, dstClient, item); err != nil {
+			printSyncItem(item, fmt.Sprintf("failed: %v", err))
+			errs = append(errs, fmt.Errorf("%s: %w", item.name, err))
+			continue
+		}
+		printSyncItem(item, "")
+	}
+	printSyncSummary(plan)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to sync %d parameter(s): %w", len(errs), errors.Join(errs...))
+	}
+	return nil
+}
+
+// mergeSyncConfig merges configuration from file with command line flags.
+// The destination role defaults to the source role.
+func mergeSyncConfig(cfg *config.Config) {
+	syncKMSKeys = nil
+	if cfg != nil {
+		if syncFromRegion == "" {
+			syncFromRegion = cfg.Region
+		}
+		if syncFromRole == "" {
+			syncFromRole = cfg.Role
+		}
+		syncKMSKeys = cfg.KMSKeys
+	}
+	if syncToRole == "" {
+		syncToRole = syncFromRole
+	}
+}
+
+// listSyncParameters reads the parameter subtree to sync keyed by name
+func listSyncParameters(ctx context.Context, client *aws.Client) (map[string]aws.Parameter, error) {
+	list, err := client.ListParameters(ctx, syncPrefix)
+	if err != nil {
+		return nil, err
+	}
+	params := make(map[string]aws.Parameter, len(list))
+	for _, param := range list {
+		params[param.Name] = param
+	}
+	return params, nil
+}
+
+// planSync compares the source and destination parameters and returns the
+// actions needed to bring the destination in line with the source, sorted by
+// parameter name with destination-only parameters last.
+func planSync(source, dest map[string]aws.Parameter, overwrite, prune bool) []syncItem {
+	var plan []syncItem
+	for _, name := range sortedKeys(source) {
+		item := syncItem{name: name, source: source[name]}
+		existing, ok := dest[name]
+		if !ok {
+			item.action = syncActionCreate
+			plan = append(plan, item)
+			continue
+		}
+
+		// A write can't clear the description, only the other differences
+		// are synced
+		compared := source[name]
+		keeps := keepsDescription(&existing, compared)
+		if keeps {
+			compared.Description = existing.Description
+		}
+		item.reasons = compareParameters(compared, existing)
+		switch {
+		case len(item.reasons) == 0:
+			item.action = syncActionUnchanged
+		case overwrite:
+			item.action = syncActionUpdate
+		default:
+			item.action = syncActionSkip
+		}
+		if keeps {
+			item.reasons = append(item.reasons, fmt.Sprintf("description %q can't be cleared by a write", existing.Description))
+		}
+		plan = append(plan, item)
+	}
+
+	for _, name := range sortedKeys(dest) {
+		if _, ok := source[name]; ok {
+			continue
+		}
+		item := syncItem{name: name, action: syncActionOrphaned}
+		if prune {
+			item.action = syncActionDelete
+		}
+		plan = append(plan, item)
+	}
+	return plan
+}
+
+// applySyncItem writes a single plan entry to the destination
+func applySyncItem(ctx context.Context, srcClient, dstClient *aws.Client, item syncItem) error {
+	switch item.action {
+	case syncActionCreate, syncActionUpdate:
+		param := item.source
+		kmsKeyID, err := syncKMSKeyID(param)
+		if err != nil {
+			return err
+		}
+
+		if err := dstClient.CreateParameter(ctx, param.Name, param.Value, param.Description, param.Type, kmsKeyID,
+			item.action == syncActionUpdate, aws.WithTier(param.Tier)); err != nil {
+			return err
+		}
+
+		tags, err := srcClient.ListTags(ctx, param.Name)
+		if err != nil {
+			return err
+		}
+		return dstClient.AddTags(ctx, param.Name, tags)
+	case syncActionDelete:
+		return dstClient.DeleteParameter(ctx, item.name)
+	}
+	return nil
+}
+
+// syncKMSKeyID returns the KMS key used for a SecureString parameter in the
+// destination, nil for other types or the default key. A key ARN contains
+// the source account, so with a different destination role it's only
+// translated to the destination region if kms_keys doesn't map it.
+func syncKMSKeyID(param aws.Parameter) (*string, error) {
+	if param.Type != aws.ParameterTypeSecureString {
+		return nil, nil
+	}
+	if syncFromRole != syncToRole && syncKMSKeys[syncToRegion] == "" && strings.HasPrefix(param.KeyID, "arn:") {
+		return nil, fmt.Errorf("KMS key %s may not be usable with the destination role, set kms_keys for region '%s'",
+			param.KeyID, syncToRegion)
+	}
+	kmsKeyID, err := kmsKeyIDForRegion(param.KeyID, syncToRegion, syncKMSKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process KMS key for destination region: %w", err)
+	}
+	return kmsKeyID, nil
+}
+
+// printSyncItem prints a single plan entry, with the result if set
+func printSyncItem(item syncItem, result string) {
+	var details []string
+	switch item.action {
+	case syncActionUpdate, syncActionUnchanged:
+		details = item.reasons
+	case syncActionSkip:
+		details = append(slices.Clone(item.reasons), "use --overwrite to update")
+	case syncActionOrphaned:
+		details = []string{"only exists in destination, use --prune to delete"}
+	}
+	if result != "" {
+		details = append(details, result)
+	}
+
+	line := fmt.Sprintf("  %-9s %s", item.action, item.name)
+	if len(details) > 0 {
+		line += " (" + strings.Join(details, ", ") + ")"
+	}
+	fmt.Println(line)
+}
+
+// printSyncSummary prints the number of parameters per action
+func printSyncSummary(plan []syncItem) {
+	counts := make(map[string]int)
+	for _, item := range plan {
+		counts[item.action]++
+	}
+	fmt.Printf("Summary: %d create, %d update, %d unchanged, %d skip, %d delete, %d orphaned\n",
+		counts[syncActionCreate], counts[syncActionUpdate], counts[syncActionUnchanged],
+		counts[syncActionSkip], counts[syncActionDelete], counts[syncActionOrphaned])
+}
+
+func init() {
+	syncCmd.Flags().StringVar(&syncPrefix, "prefix", "", "Parameter path prefix to copy recursively (required)")
+	syncCmd.Flags().StringVar(&syncFromRegion, "from-region", "", "Source AWS region (optional, default: from config or environment)")
+	syncCmd.Flags().StringVar(&syncToRegion, "to-region", "", "Destination AWS region (required)")
+	syncCmd.Flags().StringVar(&syncFromRole, "from-role", "", "AWS role ARN to assume in the source (optional)")
+	syncCmd.Flags().StringVar(&syncToRole, "to-role", "", "AWS role ARN to assume in the destination (optional, default: source role)")
+	syncCmd.Flags().BoolVar(&syncPrune, "prune", false, "Delete destination parameters that don't exist in the source")
+	syncCmd.Flags().BoolVar(&syncOverwrite, "overwrite", false, "Update destination parameters that differ from the source")
+}
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+)
+
+func setupSyncFlags() {
+	syncCmd.ResetFlags()
+	syncCmd.Flags().StringVar(&syncPrefix, "prefix", "", "Parameter path prefix to copy")
+	syncCmd.Flags().StringVar(&syncFromRegion, "from-region", "", "Source AWS region")
+	syncCmd.Flags().StringVar(&syncToRegion, "to-region", "", "Destination AWS region")
+	syncCmd.Flags().StringVar(&syncFromRole, "from-role", "", "Source AWS role ARN")
+	syncCmd.Flags().StringVar(&syncToRole, "to-role", "", "Destination AWS role ARN")
+	syncCmd.Flags().BoolVar(&syncPrune, "prune", false, "Delete destination-only parameters")
+	syncCmd.Flags().BoolVar(&syncOverwrite, "overwrite", false, "Update differing parameters")
+	testRoot.AddCommand(syncCmd)
+	setupDryRunFlag()
+}
+
+func TestPlanSync(t *testing.T) {
+	source := map[string]aws.Parameter{
+		"/app/a": {Name: "/app/a", Value: "a", Type: aws.ParameterTypeString},
+		"/app/b": {Name: "/app/b", Value: "b", Type: aws.ParameterTypeString},
+		"/app/c": {Name: "/app/c", Value: "c", Type: aws.ParameterTypeString},
+		"/app/e": {Name: "/app/e", Value: "e", Type: aws.ParameterTypeString},
+	}
+	dest := map[string]aws.Parameter{
+		"/app/b": {Name: "/app/b", Value: "b", Type: aws.ParameterTypeString},
+		"/app/c": {Name: "/app/c", Value: "old", Type: aws.ParameterTypeString},
+		"/app/d": {Name: "/app/d", Value: "d", Type: aws.ParameterTypeString},
+		"/app/e": {Name: "/app/e", Value: "e", Type: aws.ParameterTypeString, Description: "old"},
+	}
+
+	tests := []struct {
+		name      string
+		overwrite bool
+		prune     bool
+		want      []string
+	}{
+		{"default", false, false, []string{syncActionCreate, syncActionUnchanged, syncActionSkip, syncActionUnchanged, syncActionOrphaned}},
+		{"overwrite_and_prune", true, true, []string{syncActionCreate, syncActionUnchanged, syncActionUpdate, syncActionUnchanged, syncActionDelete}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, item := range planSync(source, dest, tt.overwrite, tt.prune) {
+				got = append(got, item.action)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planSync() actions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunSync(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+
+	initial := func() map[string]map[string]aws.Parameter {
+		return map[string]map[string]aws.Parameter{
+			"us-west-2": {
+				"/app/a": {Name: "/app/a", Value: "a", Type: aws.ParameterTypeString, Description: "desc a",
+					Tier: aws.ParameterTierAdvanced},
+				"/app/b": {Name: "/app/b", Value: "b", Type: aws.ParameterTypeSecureString,
+					KeyID: "arn:aws:kms:us-west-2:123456789012:key/12345678-1234-1234-1234-123456789012"},
+			},
+			"eu-west-1": {
+				"/app/b":   {Name: "/app/b", Value: "old", Type: aws.ParameterTypeSecureString},
+				"/app/old": {Name: "/app/old", Value: "old", Type: aws.ParameterTypeString},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		config  string
+		wantErr bool
+		// want is the expected value per parameter in the destination, empty if it must not exist
+		want map[string]string
+	}{
+		{
+			name: "create_only",
+			args: []string{},
+			want: map[string]string{"/app/a": "a", "/app/b": "old", "/app/old": "old"},
+		},
+		{
+			name: "overwrite_and_prune",
+			args: []string{"--overwrite", "--prune"},
+			want: map[string]string{"/app/a": "a", "/app/b": "b", "/app/old": ""},
+		},
+		{
+			name: "dry_run",
+			args: []string{"--overwrite", "--prune", "--dry-run"},
+			want: map[string]string{"/app/a": "", "/app/b": "old", "/app/old": "old"},
+		},
+		{
+			name:    "other_role_without_kms_keys",
+			args:    []string{"--overwrite", "--to-role", "arn:aws:iam::111122223333:role/params"},
+			wantErr: true,
+			want:    map[string]string{"/app/a": "", "/app/b": "old"},
+		},
+		{
+			name:   "other_role_with_kms_keys",
+			args:   []string{"--overwrite", "--to-role", "arn:aws:iam::111122223333:role/params"},
+			config: "kms_keys:\n  eu-west-1: alias/params\n",
+			want:   map[string]string{"/app/a": "a", "/app/b": "b"},
+		},
+		{
+			name:    "same_source_and_destination",
+			args:    []string{"--to-region", "us-west-2"},
+			wantErr: true,
+		},
+		{
+			name:    "missing_destination",
+			args:    []string{"--to-region", ""},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := initial()
+			store := newRegionStore(params)
+			store.tags["us-west-2"] = map[string]map[string]string{"/app/a": {"team": "platform"}}
+			store.install()
+
+			configFile := filepath.Join(ts.tmpDir, ".params2env.yaml")
+			if tt.config != "" {
+				if err := os.WriteFile(configFile, []byte(tt.config), 0600); err != nil {
+					t.Fatalf("Failed to write config: %v", err)
+				}
+				defer os.Remove(configFile)
+			}
+
+			setupSyncFlags()
+			args := []string{"sync", "--prefix", "/app", "--from-region", "us-west-2", "--to-region", "eu-west-1"}
+			testRoot.SetArgs(append(args, tt.args...))
+			err := testRoot.Execute()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runSync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for name, want := range tt.want {
+				param, ok := params["eu-west-1"][name]
+				if want == "" {
+					if ok {
+						t.Errorf("parameter '%s' exists in destination", name)
+					}
+					continue
+				}
+				if !ok || param.Value != want {
+					t.Errorf("parameter '%s' in destination = %q, want %q", name, param.Value, want)
+				}
+			}
+			if tt.want["/app/a"] == "a" {
+				if got := params["eu-west-1"]["/app/a"].Description; got != "desc a" {
+					t.Errorf("description in destination = %q, want %q", got, "desc a")
+				}
+				if got := params["eu-west-1"]["/app/a"].Tier; got != aws.ParameterTierAdvanced {
+					t.Errorf("tier in destination = %q, want %q", got, aws.ParameterTierAdvanced)
+				}
+				if got := store.tags["eu-west-1"]["/app/a"]["team"]; got != "platform" {
+					t.Errorf("tag 'team' in destination = %q, want %q", got, "platform")
+				}
+			}
+			if tt.want["/app/b"] == "b" {
+				want := "arn:aws:kms:eu-west-1:123456789012:key/12345678-1234-1234-1234-123456789012"
+				if tt.config != "" {
+					want = "alias/params"
+				}
+				if got := params["eu-west-1"]["/app/b"].KeyID; got != want {
+					t.Errorf("KMS key in destination = %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
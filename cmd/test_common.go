@@ -94,18 +94,39 @@ func buildArgs(command string, flags map[string]string) []string {
 	return args
 }
 
+// setupDryRunFlag adds the global --dry-run flag to the test root command
+// and resets it
+func setupDryRunFlag() {
+	dryRun = false
+	if testRoot.PersistentFlags().Lookup("dry-run") == nil {
+		testRoot.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the planned changes")
+	}
+}
+
 // setupCreateFlags sets up create command flags for testing
 func setupCreateFlags() {
 	createCmd.ResetFlags()
 	createCmd.Flags().StringVar(&createPath, "path", "", "Parameter path (required)")
-	createCmd.Flags().StringVar(&createValue, "value", "", "Parameter value (required)")
+	createCmd.Flags().StringArrayVar(&createValues, "value", nil, "Parameter value (required)")
+	createCmd.Flags().StringVar(&createValueFile, "value-file", "", "Read the value from a file")
+	createCmd.Flags().BoolVar(&createValueStdin, "value-stdin", false, "Read the value from stdin")
+	createCmd.Flags().BoolVar(&createTrim, "trim", false, "Trim the value")
+	createCmd.Flags().BoolVar(&createGenerate, "generate", false, "Generate a random value")
+	createCmd.Flags().StringVar(&createEncoding, "encoding", "chars", "Encoding of the generated value")
+	createCmd.Flags().IntVar(&createLength, "length", 0, "Length of the generated value")
+	createCmd.Flags().StringSliceVar(&createClasses, "classes", nil, "Character classes of the generated value")
+	createCmd.Flags().StringVar(&createExclude, "exclude-chars", "", "Excluded characters")
+	createCmd.Flags().BoolVar(&createPrint, "print", false, "Print the generated value")
 	createCmd.Flags().StringVar(&createType, "type", "String", "Parameter type")
 	createCmd.Flags().StringVar(&createDesc, "description", "", "Parameter description")
 	createCmd.Flags().StringVar(&createKMS, "kms", "", "KMS key ID")
 	createCmd.Flags().StringVar(&createRegion, "region", "", "AWS region")
 	createCmd.Flags().StringVar(&createRole, "role", "", "AWS role ARN")
-	createCmd.Flags().StringVar(&createReplica, "replica", "", "Replica region")
+	createCmd.Flags().StringSliceVar(&createReplicas, "replica", nil, "Replica regions")
+	createCmd.Flags().StringToStringVar(&createReplicaKMS, "replica-kms", nil, "KMS key ID per replica region")
 	createCmd.Flags().BoolVar(&createOverwrite, "overwrite", false, "Overwrite existing")
+	createCmd.Flags().BoolVar(&createAtomic, "atomic", false, "Roll back on failure")
+	createCmd.Flags().BoolVar(&createForce, "force", false, "Write unchanged parameters")
 }
 
 // setupModifyFlags sets up modify command flags for testing
@@ -113,8 +134,18 @@ func setupModifyFlags() {
 	modifyCmd.ResetFlags()
 	modifyCmd.Flags().StringVar(&modifyPath, "path", "", "Parameter path (required)")
 	modifyCmd.Flags().StringVar(&modifyValue, "value", "", "Parameter value (required)")
+	modifyCmd.Flags().StringVar(&modifyValueFile, "value-file", "", "Read the value from a file")
+	modifyCmd.Flags().BoolVar(&modifyValueStdin, "value-stdin", false, "Read the value from stdin")
+	modifyCmd.Flags().BoolVar(&modifyTrim, "trim", false, "Trim the value")
 	modifyCmd.Flags().StringVar(&modifyDesc, "description", "", "Parameter description")
 	modifyCmd.Flags().StringVar(&modifyRegion, "region", "", "AWS region")
 	modifyCmd.Flags().StringVar(&modifyRole, "role", "", "AWS role ARN")
-	modifyCmd.Flags().StringVar(&modifyReplica, "replica", "", "Replica region")
+	modifyCmd.Flags().StringSliceVar(&modifyReplicas, "replica", nil, "Replica regions")
+	modifyCmd.Flags().BoolVar(&modifyAtomic, "atomic", false, "Roll back on failure")
+	modifyCmd.Flags().StringVar(&modifyType, "type", "", "New parameter type")
+	modifyCmd.Flags().StringVar(&modifyKMS, "kms", "", "New KMS key ID")
+	modifyCmd.Flags().StringToStringVar(&modifyReplicaKMS, "replica-kms", nil, "KMS key ID per replica region")
+	modifyCmd.Flags().BoolVar(&modifyForce, "force", false, "Write unchanged parameters")
+	modifyCmd.Flags().Int64Var(&modifyExpectVersion, "expect-version", 0, "Expected version")
+	modifyCmd.Flags().StringVar(&modifyExpectHash, "expect-value-hash", "", "Expected value hash")
 }
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+)
+
+// regionSnapshot holds the state of a parameter in a region before it was written
+type regionSnapshot struct {
+	// region is the AWS region of the snapshot
+	region string
+	// prior is the parameter before the write, nil if it didn't exist
+	prior *aws.Parameter
+	// tags are the tags of prior
+	tags map[string]string
+}
+
+// runAtomic writes a parameter to the primary region and all replica regions.
+// The state of the parameter in every region is recorded before the first
+// write. If any region fails, all regions that were already written are
+// restored to their recorded state: newly created parameters are deleted and
+// previous values are written back. Regions whose write was skipped because
+// the parameter was unchanged are not restored. The action is used in
+// messages, e.g. "create".
+func runAtomic(action, path, role, primary string, replicas []string, writePrimary func() error, writeReplica func(region string) error) error {
+	ctx := context.Background()
+	regions := append([]string{primary}, replicas...)
+
+	snapshots, err := captureSnapshots(ctx, path, role, regions)
+	if err != nil {
+		return fmt.Errorf("failed to record state of parameter '%s' before %s: %w", path, action, err)
+	}
+
+	// Nothing was written yet if the primary region fails
+	var written []string
+	switch err := writePrimary(); {
+	case err == nil:
+		written = append(written, primary)
+	case !errors.Is(err, errUnchanged):
+		return err
+	}
+
+	results := runInRegions(replicas, writeReplica)
+	writeErr := reportRegionResults(action, path, results)
+	if writeErr == nil {
+		return nil
+	}
+
+	for _, result := range results {
+		if result.err == nil {
+			written = append(written, result.region)
+		}
+	}
+
+	if len(written) == 0 {
+		return fmt.Errorf("%w (no changes to roll back)", writeErr)
+	}
+
+	fmt.Printf("Rolling back parameter '%s' in %d region(s)\n", path, len(written))
+	if err := rollbackRegions(ctx, path, role, snapshots, written); err != nil {
+		return errors.Join(writeErr, fmt.Errorf("rollback incomplete: %w", err))
+	}
+	return fmt.Errorf("%w (changes rolled back)", writeErr)
+}
+
+// captureSnapshots records the current state of a parameter in all regions
+func captureSnapshots(ctx context.Context, path, role string, regions []string) (map[string]regionSnapshot, error) {
+	var mu sync.Mutex
+	snapshots := make(map[string]regionSnapshot, len(regions))
+
+	results := runInRegions(regions, func(region string) error {
+		client, err := aws.NewClient(ctx, region, role)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+
+		snapshot := regionSnapshot{region: region}
+		prior, err := client.DescribeParameter(ctx, path)
+		switch {
+		case err == nil:
+			snapshot.prior = prior
+		case !errors.Is(err, aws.ErrNotFound):
+			return err
+		}
+		if snapshot.prior != nil {
+			if snapshot.tags, err = client.ListTags(ctx, path); err != nil {
+				return err
+			}
+		}
+
+		mu.Lock()
+		snapshots[region] = snapshot
+		mu.Unlock()
+		return nil
+	})
+
+	var errs []error
+	for _, result := range results {
+		if result.err != nil {
+			errs = append(errs, fmt.Errorf("region '%s': %w", result.region, result.err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return snapshots, nil
+}
+
+// rollbackRegions restores the recorded state of a parameter in the given
+// regions and reports the outcome for every region.
+func rollbackRegions(ctx context.Context, path, role string, snapshots map[string]regionSnapshot, regions []string) error {
+	results := runInRegions(regions, func(region string) error {
+		return restoreSnapshot(ctx, path, role, snapshots[region])
+	})
+
+	var errs []error
+	for _, result := range results {
+		snapshot := snapshots[result.region]
+		if result.err != nil {
+			fmt.Printf("Failed to roll back parameter '%s' in region '%s': %v\n", path, result.region, result.err)
+			errs = append(errs, fmt.Errorf("region '%s': %w", result.region, result.err))
+			continue
+		}
+		if snapshot.prior == nil {
+			fmt.Printf("Rolled back parameter '%s' in region '%s': deleted\n", path, result.region)
+		} else {
+			fmt.Printf("Rolled back parameter '%s' in region '%s': restored value of version %d\n", path, result.region, snapshot.prior.Version)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// restoreSnapshot writes the recorded state of a parameter back to its region,
+// including its tier and tags. A parameter that didn't exist before is deleted. A description that was
+// added by the failed change can't be cleared by a write, which is returned
+// as an error after the value is restored.
+func restoreSnapshot(ctx context.Context, path, role string, snapshot regionSnapshot) error {
+	client, err := aws.NewClient(ctx, snapshot.region, role)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	if snapshot.prior == nil {
+		if err := client.DeleteParameter(ctx, path); err != nil && !errors.Is(err, aws.ErrNotFound) {
+			return err
+		}
+		return nil
+	}
+
+	prior := snapshot.prior
+	current, err := client.DescribeParameter(ctx, path)
+	if err != nil && !errors.Is(err, aws.ErrNotFound) {
+		return err
+	}
+
+	var kmsKeyID *string
+	if prior.KeyID != "" {
+		kmsKeyID = &prior.KeyID
+	}
+	if err := client.CreateParameter(ctx, path, prior.Value, prior.Description, prior.Type, kmsKeyID, true,
+		aws.WithTier(prior.Tier)); err != nil {
+		return err
+	}
+	if err := client.AddTags(ctx, path, snapshot.tags); err != nil {
+		return err
+	}
+
+	if keepsDescription(current, *prior) {
+		return fmt.Errorf("restored value of version %d, but description %q can't be cleared by a write, remove it manually",
+			prior.Version, current.Description)
+	}
+	return nil
+}
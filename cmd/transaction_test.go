@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+)
+
+func TestRunAtomic(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+	defer func() { createAtomic, modifyAtomic, deleteAtomic = false, false, false }()
+
+	existing := func() map[string]map[string]aws.Parameter {
+		return map[string]map[string]aws.Parameter{
+			"us-west-2": {"/app/a": {Name: "/app/a", Value: "old", Type: aws.ParameterTypeString, Description: "desc", Tier: aws.ParameterTierAdvanced, Version: 3}},
+			"eu-west-1": {"/app/a": {Name: "/app/a", Value: "old", Type: aws.ParameterTypeString, Description: "desc", Tier: aws.ParameterTierAdvanced, Version: 3}},
+			"us-east-1": {"/app/a": {Name: "/app/a", Value: "old", Type: aws.ParameterTypeString, Description: "desc", Tier: aws.ParameterTierAdvanced, Version: 3}},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		setup      func()
+		args       []string
+		params     map[string]map[string]aws.Parameter
+		failWrites map[string]bool
+		wantErr    bool
+		// wantErrText is a part of the expected error, if set
+		wantErrText string
+		// want is the expected value per region, empty if the parameter must not exist
+		want map[string]string
+		// description is the expected description of restored parameters
+		description string
+		// tier is the expected tier of restored parameters
+		tier string
+		// untouched are the regions that must not be written
+		untouched []string
+	}{
+		{
+			name:  "create_succeeds",
+			setup: setupCreateFlags,
+			args:  []string{"create", "--path", "/app/a", "--value", "new"},
+			params: map[string]map[string]aws.Parameter{
+				"us-west-2": {}, "eu-west-1": {}, "us-east-1": {},
+			},
+			want: map[string]string{"us-west-2": "new", "eu-west-1": "new", "us-east-1": "new"},
+		},
+		{
+			name:  "create_rolled_back",
+			setup: setupCreateFlags,
+			args:  []string{"create", "--path", "/app/a", "--value", "new"},
+			params: map[string]map[string]aws.Parameter{
+				"us-west-2": {}, "eu-west-1": {}, "us-east-1": {},
+			},
+			failWrites: map[string]bool{"us-east-1": true},
+			wantErr:    true,
+			want:       map[string]string{"us-west-2": "", "eu-west-1": "", "us-east-1": ""},
+		},
+		{
+			name:        "modify_rolled_back",
+			setup:       setupModifyFlags,
+			args:        []string{"modify", "--path", "/app/a", "--value", "new"},
+			params:      existing(),
+			failWrites:  map[string]bool{"us-east-1": true},
+			wantErr:     true,
+			want:        map[string]string{"us-west-2": "old", "eu-west-1": "old", "us-east-1": "old"},
+			description: "desc",
+			tier:        aws.ParameterTierAdvanced,
+		},
+		{
+			name:  "unchanged_primary_not_rolled_back",
+			setup: setupModifyFlags,
+			args:  []string{"modify", "--path", "/app/a", "--value", "old"},
+			params: func() map[string]map[string]aws.Parameter {
+				params := existing()
+				params["us-east-1"]["/app/a"] = aws.Parameter{Name: "/app/a", Value: "older", Type: aws.ParameterTypeString, Version: 2}
+				return params
+			}(),
+			failWrites:  map[string]bool{"us-east-1": true},
+			wantErr:     true,
+			want:        map[string]string{"us-west-2": "old", "eu-west-1": "old"},
+			description: "desc",
+			tier:        aws.ParameterTierAdvanced,
+			untouched:   []string{"us-west-2", "eu-west-1"},
+		},
+		{
+			name:  "added_description_not_rolled_back",
+			setup: setupModifyFlags,
+			args:  []string{"modify", "--path", "/app/a", "--value", "new", "--description", "added"},
+			params: map[string]map[string]aws.Parameter{
+				"us-west-2": {"/app/a": {Name: "/app/a", Value: "old", Type: aws.ParameterTypeString, Version: 3}},
+				"eu-west-1": {"/app/a": {Name: "/app/a", Value: "old", Type: aws.ParameterTypeString, Version: 3}},
+				"us-east-1": {"/app/a": {Name: "/app/a", Value: "old", Type: aws.ParameterTypeString, Version: 3}},
+			},
+			failWrites:  map[string]bool{"us-east-1": true},
+			wantErr:     true,
+			wantErrText: "rollback incomplete",
+			want:        map[string]string{"us-west-2": "old", "eu-west-1": "old"},
+			description: "added",
+		},
+		{
+			name:        "delete_rolled_back",
+			setup:       func() { setupDeleteFlags(t) },
+			args:        []string{"delete", "--path", "/app/a"},
+			params:      existing(),
+			failWrites:  map[string]bool{"us-east-1": true},
+			wantErr:     true,
+			want:        map[string]string{"us-west-2": "old", "eu-west-1": "old", "us-east-1": "old"},
+			description: "desc",
+			tier:        aws.ParameterTierAdvanced,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newRegionStore(tt.params)
+			for region, params := range tt.params {
+				if _, ok := params["/app/a"]; ok {
+					store.tags[region] = map[string]map[string]string{"/app/a": {"team": "platform"}}
+				}
+			}
+			store.failWrites = tt.failWrites
+			store.install()
+
+			tt.setup()
+			testRoot.AddCommand(createCmd, modifyCmd, deleteCmd)
+			testRoot.SetArgs(append(tt.args, "--region", "us-west-2", "--replica", "eu-west-1,us-east-1", "--atomic"))
+			err := testRoot.Execute()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runAtomic() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrText != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErrText)) {
+				t.Errorf("runAtomic() error = %v, want error containing %q", err, tt.wantErrText)
+			}
+			for _, region := range tt.untouched {
+				if puts := store.puts[region]; len(puts) > 0 {
+					t.Errorf("parameter written %d time(s) in untouched region '%s'", len(puts), region)
+				}
+			}
+			for region, want := range tt.want {
+				param, ok := tt.params[region]["/app/a"]
+				if want == "" {
+					if ok {
+						t.Errorf("parameter exists in region '%s' after rollback", region)
+					}
+					continue
+				}
+				if !ok || param.Value != want {
+					t.Errorf("parameter in region '%s' = %q, want %q", region, param.Value, want)
+				}
+				if want == "old" && param.Description != tt.description {
+					t.Errorf("description in region '%s' = %q, want %q", region, param.Description, tt.description)
+				}
+				if want == "old" && param.Tier != tt.tier {
+					t.Errorf("tier in region '%s' = %q, want %q", region, param.Tier, tt.tier)
+				}
+				if want == "old" && store.tags[region]["/app/a"]["team"] != "platform" {
+					t.Errorf("tag 'team' in region '%s' = %q, want %q", region, store.tags[region]["/app/a"]["team"], "platform")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// isTerminal reports whether the standard input is an interactive terminal,
+// overridden in tests
+var isTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// readPassword reads a line from the terminal without echoing it,
+// overridden in tests
+var readPassword = func() ([]byte, error) {
+	return term.ReadPassword(int(os.Stdin.Fd()))
+}
+
+// validateValueSources checks that at most one of --value, --value-file and
+// --value-stdin is set. Without any of them, the value is prompted for,
+// which requires an interactive terminal.
+func validateValueSources(hasValue bool, file string, fromStdin bool) error {
+	sources := 0
+	for _, set := range []bool{hasValue, file != "", fromStdin} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("\"value\", \"value-file\" and \"value-stdin\" are mutually exclusive")
+	}
+	if sources == 0 && !isTerminal() {
+		return fmt.Errorf("required flag \"value\" not set")
+	}
+	return nil
+}
+
+// readValue reads a parameter value from a file or the standard input. The
+// bytes are kept as they are, including trailing newlines, unless trim is
+// set, which removes leading and trailing whitespace.
+func readValue(file string, fromStdin, trim bool) (string, error) {
+	var data []byte
+	var err error
+	switch {
+	case file != "":
+		if data, err = os.ReadFile(file); err != nil {
+			return "", fmt.Errorf("failed to read value file: %w", err)
+		}
+	case fromStdin:
+		if data, err = io.ReadAll(stdin); err != nil {
+			return "", fmt.Errorf("failed to read value from stdin: %w", err)
+		}
+	}
+
+	value := string(data)
+	if trim {
+		value = strings.TrimSpace(value)
+	}
+	if value == "" {
+		return "", fmt.Errorf("value is empty")
+	}
+	return value, nil
+}
+
+// promptValue asks for the value of a parameter on the terminal without
+// echoing the input. With confirm set, the value has to be entered twice.
+func promptValue(path string, confirm bool) (string, error) {
+	return promptSecret(fmt.Sprintf("Value for '%s'", path), "value", confirm)
+}
+
+// promptSecret reads a secret from the terminal without echoing it. label is
+// the prompt and noun names the secret in errors. With confirm set, the
+// secret has to be entered twice. The prompts are written to stderr to keep
+// stdout clean.
+func promptSecret(label, noun string, confirm bool) (string, error) {
+	fmt.Fprintf(os.Stderr, "%s: ", label)
+	secret, err := readPassword()
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", noun, err)
+	}
+	if len(secret) == 0 {
+		return "", fmt.Errorf("%s is empty", noun)
+	}
+
+	if confirm {
+		fmt.Fprintf(os.Stderr, "Confirm %s%s: ", strings.ToLower(label[:1]), label[1:])
+		again, err := readPassword()
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", noun, err)
+		}
+		if string(again) != string(secret) {
+			return "", fmt.Errorf("%ss don't match", noun)
+		}
+	}
+	return string(secret), nil
+}
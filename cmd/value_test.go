@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+)
+
+func TestValidateValueSources(t *testing.T) {
+	origIsTerminal := isTerminal
+	defer func() { isTerminal = origIsTerminal }()
+
+	tests := []struct {
+		name      string
+		hasValue  bool
+		file      string
+		fromStdin bool
+		terminal  bool
+		wantErr   string
+	}{
+		{name: "value", hasValue: true},
+		{name: "file", file: "value.txt"},
+		{name: "stdin", fromStdin: true},
+		{name: "prompt_on_terminal", terminal: true},
+		{name: "none_without_terminal", wantErr: "required flag \"value\" not set"},
+		{name: "value_and_file", hasValue: true, file: "value.txt", wantErr: "mutually exclusive"},
+		{name: "file_and_stdin", file: "value.txt", fromStdin: true, wantErr: "mutually exclusive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isTerminal = func() bool { return tt.terminal }
+			err := validateValueSources(tt.hasValue, tt.file, tt.fromStdin)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateValueSources() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateValueSources() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadValue(t *testing.T) {
+	defer func() { stdin = os.Stdin }()
+
+	file := filepath.Join(t.TempDir(), "value.txt")
+	if err := os.WriteFile(file, []byte("line1\nline2\n"), 0600); err != nil {
+		t.Fatalf("Failed to write value file: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		file      string
+		input     string
+		fromStdin bool
+		trim      bool
+		want      string
+		wantErr   bool
+	}{
+		{name: "file_keeps_newline", file: file, want: "line1\nline2\n"},
+		{name: "file_trimmed", file: file, trim: true, want: "line1\nline2"},
+		{name: "file_missing", file: filepath.Join(t.TempDir(), "missing"), wantErr: true},
+		{name: "stdin_keeps_bytes", fromStdin: true, input: "  s3cr3t\r\n", want: "  s3cr3t\r\n"},
+		{name: "stdin_trimmed", fromStdin: true, input: "  s3cr3t\r\n", trim: true, want: "s3cr3t"},
+		{name: "stdin_empty", fromStdin: true, input: "", wantErr: true},
+		{name: "stdin_only_whitespace_trimmed", fromStdin: true, input: "\n", trim: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdin = strings.NewReader(tt.input)
+			got, err := readValue(tt.file, tt.fromStdin, tt.trim)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("readValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromptValue(t *testing.T) {
+	origReadPassword := readPassword
+	defer func() { readPassword = origReadPassword }()
+
+	tests := []struct {
+		name    string
+		inputs  []string
+		confirm bool
+		want    string
+		wantErr bool
+	}{
+		{name: "single", inputs: []string{"s3cr3t"}, want: "s3cr3t"},
+		{name: "confirmed", inputs: []string{"s3cr3t", "s3cr3t"}, confirm: true, want: "s3cr3t"},
+		{name: "mismatch", inputs: []string{"s3cr3t", "other"}, confirm: true, wantErr: true},
+		{name: "empty", inputs: []string{""}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputs := tt.inputs
+			readPassword = func() ([]byte, error) {
+				if len(inputs) == 0 {
+					return nil, errors.New("no input")
+				}
+				input := inputs[0]
+				inputs = inputs[1:]
+				return []byte(input), nil
+			}
+
+			got, err := promptValue("/app/a", tt.confirm)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("promptValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("promptValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValueSources(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+	origIsTerminal, origReadPassword := isTerminal, readPassword
+	defer func() {
+		stdin = os.Stdin
+		isTerminal, readPassword = origIsTerminal, origReadPassword
+	}()
+
+	file := filepath.Join(ts.tmpDir, "cert.pem")
+	if err := os.WriteFile(file, []byte("-----BEGIN-----\nabc\n-----END-----\n"), 0600); err != nil {
+		t.Fatalf("Failed to write value file: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		setup  func()
+		args   []string
+		input  string
+		prompt []string
+		want   string
+	}{
+		{
+			name:  "create_from_file",
+			setup: setupCreateFlags,
+			args:  []string{"create", "--path", "/app/new", "--value-file", file},
+			want:  "-----BEGIN-----\nabc\n-----END-----\n",
+		},
+		{
+			name:  "create_from_stdin_trimmed",
+			setup: setupCreateFlags,
+			args:  []string{"create", "--path", "/app/new", "--value-stdin", "--trim"},
+			input: "s3cr3t\n",
+			want:  "s3cr3t",
+		},
+		{
+			name:   "create_secure_prompted",
+			setup:  setupCreateFlags,
+			args:   []string{"create", "--path", "/app/new", "--type", "SecureString", "--kms", "alias/mykey"},
+			prompt: []string{"s3cr3t", "s3cr3t"},
+			want:   "s3cr3t",
+		},
+		{
+			name:  "modify_from_stdin",
+			setup: setupModifyFlags,
+			args:  []string{"modify", "--path", "/app/new", "--value-stdin"},
+			input: "new\n",
+			want:  "new\n",
+		},
+		{
+			name:   "modify_secure_prompted",
+			setup:  setupModifyFlags,
+			args:   []string{"modify", "--path", "/app/new"},
+			prompt: []string{"new", "new"},
+			want:   "new",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := map[string]map[string]aws.Parameter{"us-west-2": {}}
+			if strings.HasPrefix(tt.name, "modify") {
+				params["us-west-2"]["/app/new"] = aws.Parameter{
+					Name: "/app/new", Value: "old", Type: aws.ParameterTypeSecureString, KeyID: "alias/mykey", Version: 1,
+				}
+			}
+			newRegionStore(params).install()
+
+			stdin = strings.NewReader(tt.input)
+			prompt := tt.prompt
+			isTerminal = func() bool { return prompt != nil }
+			readPassword = func() ([]byte, error) {
+				if len(prompt) == 0 {
+					return nil, errors.New("unexpected prompt")
+				}
+				input := prompt[0]
+				prompt = prompt[1:]
+				return []byte(input), nil
+			}
+
+			tt.setup()
+			testRoot.AddCommand(createCmd, modifyCmd)
+			testRoot.SetArgs(append(tt.args, "--region", "us-west-2"))
+			if err := testRoot.Execute(); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if got := params["us-west-2"]["/app/new"].Value; got != tt.want {
+				t.Errorf("parameter value = %q, want %q", got, tt.want)
+			}
+			if len(prompt) != 0 {
+				t.Errorf("%d prompt input(s) not read", len(prompt))
+			}
+		})
+	}
+}
@@ -0,0 +1,397 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+	"git.sr.ht/~wombelix/params2env/internal/config"
+	"git.sr.ht/~wombelix/params2env/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+// Command-line flags for the verify command
+var (
+	// verifyPath is the full path of a single parameter to verify
+	verifyPath string
+	// verifyPrefix is the path of a parameter subtree to verify
+	verifyPrefix string
+	// verifyRegion is the primary AWS region
+	verifyRegion string
+	// verifyRole is the AWS IAM role to assume for the operation
+	verifyRole string
+	// verifyReplicas are the replica regions compared against the primary region
+	verifyReplicas []string
+	// verifyFix pushes the primary state to drifted replicas
+	verifyFix bool
+)
+
+// verifyKMSKeys maps a replica region to the KMS key ID used by --fix,
+// taken from the kms_keys configuration setting
+var verifyKMSKeys map[string]string
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Compare parameters between the primary and replica regions",
+	Long: `Compare parameters between the primary and replica regions.
+
+The value, type and description of a parameter or a parameter subtree
+are compared across regions. Values are never printed, not even as a hash.
+The command exits with an error if drift is detected.
+
+With --fix, the primary value, type and description are written to every
+replica region that is missing the parameter or differs from the primary.
+Parameters that only exist in a replica region are reported but not removed.
+
+Examples:
+  # Verify a single parameter
+  params2env verify --path /myapp/config/url --replica eu-west-1
+
+  # Verify a parameter subtree in multiple replica regions
+  params2env verify --prefix /myapp --replica eu-west-1,us-east-1
+
+  # Verify and repair drifted replicas
+  params2env verify --prefix /myapp --replica eu-west-1 --fix`,
+	PreRunE: validateVerifyFlags,
+	RunE:    runVerify,
+}
+
+// parameterDrift describes the differences of a single parameter between
+// the primary region and a replica region
+type parameterDrift struct {
+	// name is the full path of the parameter
+	name string
+	// reasons lists the detected differences
+	reasons []string
+	// extra is set if the parameter only exists in the replica region
+	extra bool
+	// replica is the parameter in the replica region, nil if it's missing
+	replica *aws.Parameter
+}
+
+// validateVerifyFlags checks if all required flags are set and valid
+func validateVerifyFlags(cmd *cobra.Command, args []string) error {
+	if verifyPath == "" && verifyPrefix == "" {
+		return fmt.Errorf("either \"path\" or \"prefix\" must be set")
+	}
+	if verifyPath != "" && verifyPrefix != "" {
+		return fmt.Errorf("\"path\" and \"prefix\" are mutually exclusive")
+	}
+	if verifyPath != "" {
+		if err := validation.ValidateParameterPath(verifyPath); err != nil {
+			return err
+		}
+	}
+	if verifyPrefix != "" {
+		if err := validation.ValidateParameterPath(verifyPrefix); err != nil {
+			return err
+		}
+	}
+
+	if err := validation.ValidateRegion(verifyRegion); err != nil {
+		return err
+	}
+
+	for _, replica := range verifyReplicas {
+		if err := validation.ValidateRegion(replica); err != nil {
+			return fmt.Errorf("invalid replica region: %w", err)
+		}
+	}
+
+	if err := validation.ValidateRoleARN(verifyRole); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// runVerify executes the verify command
+func runVerify(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Merge config with flags (flags take precedence)
+	mergeVerifyConfig(cfg)
+
+	// Ensure region is set
+	if verifyRegion == "" {
+		if verifyRegion = os.Getenv("AWS_REGION"); verifyRegion == "" {
+			return fmt.Errorf("AWS region must be specified via --region, config file, or AWS_REGION environment variable")
+		}
+	}
+
+	if len(verifyReplicas) == 0 {
+		return fmt.Errorf("at least one replica region must be specified via --replica or config file")
+	}
+
+	// Validate replica regions differ from the primary region and each other
+	if err := validation.ValidateReplicaRegions(verifyRegion, verifyReplicas); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	primary, err := fetchVerifyParameters(ctx, verifyRegion)
+	if err != nil {
+		return fmt.Errorf("failed to read parameters in primary region '%s': %w", verifyRegion, err)
+	}
+	if verifyPath != "" && len(primary) == 0 {
+		return fmt.Errorf("parameter '%s' not found in region '%s'", verifyPath, verifyRegion)
+	}
+
+	var mu sync.Mutex
+	drifts := make(map[string][]parameterDrift, len(verifyReplicas))
+	results := runInRegions(verifyReplicas, func(replica string) error {
+		replicaParams, err := fetchVerifyParameters(ctx, replica)
+		if err != nil {
+			return err
+		}
+		regionDrifts := compareRegions(primary, replicaParams)
+		mu.Lock()
+		drifts[replica] = regionDrifts
+		mu.Unlock()
+		return nil
+	})
+
+	return reportVerifyResults(ctx, primary, results, drifts)
+}
+
+// mergeVerifyConfig merges configuration from file with command line flags
+func mergeVerifyConfig(cfg *config.Config) {
+	if cfg == nil {
+		return
+	}
+	if verifyRegion == "" {
+		verifyRegion = cfg.Region
+	}
+	if len(verifyReplicas) == 0 {
+		verifyReplicas = cfg.ReplicaRegions()
+	}
+	if verifyRole == "" {
+		verifyRole = cfg.Role
+	}
+	verifyKMSKeys = cfg.KMSKeys
+}
+
+// fetchVerifyParameters reads the parameter or subtree to verify from a region.
+// A missing single parameter results in an empty list.
+func fetchVerifyParameters(ctx context.Context, region string) (map[string]aws.Parameter, error) {
+	client, err := aws.NewClient(ctx, region, verifyRole)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS client: %w", err)
+	}
+
+	params := make(map[string]aws.Parameter)
+	if verifyPath != "" {
+		param, err := client.DescribeParameter(ctx, verifyPath)
+		if err != nil {
+			if errors.Is(err, aws.ErrNotFound) {
+				return params, nil
+			}
+			return nil, err
+		}
+		params[param.Name] = *param
+		return params, nil
+	}
+
+	list, err := client.ListParameters(ctx, verifyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range list {
+		params[param.Name] = param
+	}
+	return params, nil
+}
+
+// compareRegions compares the parameters of the primary region with those of
+// a replica region and returns the detected drift sorted by parameter name.
+func compareRegions(primary, replica map[string]aws.Parameter) []parameterDrift {
+	var drifts []parameterDrift
+	for _, name := range sortedKeys(primary) {
+		p := primary[name]
+		r, ok := replica[name]
+		if !ok {
+			drifts = append(drifts, parameterDrift{name: name, reasons: []string{"missing in replica"}})
+			continue
+		}
+		if reasons := compareParameters(p, r); len(reasons) > 0 {
+			drifts = append(drifts, parameterDrift{name: name, reasons: reasons, replica: &r})
+		}
+	}
+	for _, name := range sortedKeys(replica) {
+		if _, ok := primary[name]; !ok {
+			drifts = append(drifts, parameterDrift{name: name, reasons: []string{"only exists in replica"}, extra: true})
+		}
+	}
+	return drifts
+}
+
+// compareParameters returns the differences between two parameters.
+// Values are never included in the result, not even as a hash.
+func compareParameters(primary, replica aws.Parameter) []string {
+	var reasons []string
+	if primary.Value != replica.Value {
+		reasons = append(reasons, "value differs")
+	}
+	if primary.Type != replica.Type {
+		reasons = append(reasons, fmt.Sprintf("type differs (%s != %s)", primary.Type, replica.Type))
+	}
+	if primary.Description != replica.Description {
+		reasons = append(reasons, fmt.Sprintf("description differs (%q != %q)", primary.Description, replica.Description))
+	}
+	return reasons
+}
+
+// hashValue returns the hex encoded SHA-256 hash of a parameter value
+func hashValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// reportVerifyResults prints the drift per replica region, repairs it if
+// --fix is set and returns an error if drift remains or a region failed.
+// With --dry-run the repair is only printed.
+func reportVerifyResults(ctx context.Context, primary map[string]aws.Parameter, results []regionResult, drifts map[string][]parameterDrift) error {
+	var errs []error
+	remaining := 0
+	if verifyFix && dryRun {
+		fmt.Println("Dry run, no drift is fixed")
+	}
+	for _, result := range results {
+		if result.err != nil {
+			fmt.Printf("Failed to verify replica region '%s': %v\n", result.region, result.err)
+			errs = append(errs, fmt.Errorf("replica region '%s': %w", result.region, result.err))
+			continue
+		}
+
+		regionDrifts := drifts[result.region]
+		if len(regionDrifts) == 0 {
+			fmt.Printf("Replica region '%s' is in sync with '%s'\n", result.region, verifyRegion)
+			continue
+		}
+
+		fmt.Printf("Drift detected in replica region '%s':\n", result.region)
+		for _, drift := range regionDrifts {
+			fmt.Printf("  %s: %s\n", drift.name, strings.Join(drift.reasons, ", "))
+			if !verifyFix || drift.extra {
+				remaining++
+				continue
+			}
+			if dryRun {
+				p := planFix(result.region, drift.replica, primary[drift.name])
+				if p.action == planActionFail {
+					remaining++
+				}
+				fmt.Printf("  %s: would fix, %s (%s)\n", drift.name, p.action, strings.Join(p.details, ", "))
+				continue
+			}
+			if err := fixDrift(ctx, result.region, drift.replica, primary[drift.name]); err != nil {
+				fmt.Printf("  %s: fix failed: %v\n", drift.name, err)
+				remaining++
+				continue
+			}
+			fmt.Printf("  %s: fixed\n", drift.name)
+		}
+	}
+
+	if remaining > 0 {
+		errs = append(errs, fmt.Errorf("drift detected in %d parameter(s)", remaining))
+	}
+	return errors.Join(errs...)
+}
+
+// fixDrift writes the primary parameter over the current one in a replica
+// region. A description that was cleared in the primary region can't be
+// cleared by a write, which is returned as an error after the other
+// differences are fixed.
+func fixDrift(ctx context.Context, replica string, current *aws.Parameter, param aws.Parameter) error {
+	keeps := keepsDescription(current, param)
+	// Skip the write if only the description differs, it wouldn't fix it
+	if !keeps || len(compareParameters(param, *current)) > 1 {
+		client, err := aws.NewClient(ctx, replica, verifyRole)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client for replica region: %w", err)
+		}
+
+		kmsKeyID, err := replicaKMSKeyID(replica, param)
+		if err != nil {
+			return err
+		}
+
+		if err := client.CreateParameter(ctx, param.Name, param.Value, param.Description, param.Type, kmsKeyID, true); err != nil {
+			return err
+		}
+	}
+
+	if keeps {
+		return fmt.Errorf("description %q can't be cleared by a write, remove it manually", current.Description)
+	}
+	return nil
+}
+
+// planFix returns the plan to write the primary parameter over the current
+// one in a replica region, values are never included
+func planFix(replica string, current *aws.Parameter, param aws.Parameter) regionPlan {
+	kmsKeyID, err := replicaKMSKeyID(replica, param)
+	if err != nil {
+		return regionPlan{action: planActionFail, details: []string{err.Error()}}
+	}
+	desired := param
+	desired.KeyID = ""
+	if kmsKeyID != nil {
+		desired.KeyID = *kmsKeyID
+	}
+	p := planWrite(current, desired, true)
+	if keepsDescription(current, param) {
+		p.action = planActionFail
+		p.details = append(p.details, fmt.Sprintf("description %q can't be cleared by a write", current.Description))
+	}
+	return p
+}
+
+// replicaKMSKeyID returns the KMS key used for a SecureString parameter in
+// a replica region, nil for other types or the default key
+func replicaKMSKeyID(replica string, param aws.Parameter) (*string, error) {
+	if param.Type != aws.ParameterTypeSecureString {
+		return nil, nil
+	}
+	kmsKeyID, err := kmsKeyIDForRegion(param.KeyID, replica, verifyKMSKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process KMS key for replica region: %w", err)
+	}
+	return kmsKeyID, nil
+}
+
+// sortedKeys returns the keys of a parameter map in sorted order
+func sortedKeys(params map[string]aws.Parameter) []string {
+	keys := make([]string, 0, len(params))
+	for name := range params {
+		keys = append(keys, name)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func init() {
+	verifyCmd.Flags().StringVar(&verifyPath, "path", "", "Parameter path to verify")
+	verifyCmd.Flags().StringVar(&verifyPrefix, "prefix", "", "Parameter path prefix to verify recursively")
+	verifyCmd.Flags().StringVar(&verifyRegion, "region", "", "AWS region (optional, default: from AWS config or environment)")
+	verifyCmd.Flags().StringVar(&verifyRole, "role", "", "AWS role ARN to assume (optional)")
+	verifyCmd.Flags().StringSliceVar(&verifyReplicas, "replica", nil, "Replica regions to compare (default: from config)")
+	verifyCmd.Flags().BoolVar(&verifyFix, "fix", false, "Write the primary state to drifted replica regions")
+}
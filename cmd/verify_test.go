@@ -0,0 +1,393 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// regionStore is an in-memory parameter store per region for testing
+type regionStore struct {
+	mu     sync.Mutex
+	params map[string]map[string]aws.Parameter
+	puts   map[string][]string
+	// failWrites makes all writes in a region fail
+	failWrites map[string]bool
+	// tags holds the tags per region and parameter name
+	tags map[string]map[string]map[string]string
+}
+
+// newRegionStore creates a regionStore with the given parameters per region
+func newRegionStore(params map[string]map[string]aws.Parameter) *regionStore {
+	return &regionStore{
+		params: params,
+		puts:   make(map[string][]string),
+		tags:   make(map[string]map[string]map[string]string),
+	}
+}
+
+// client returns a mock SSM client backed by the parameters of a region
+func (rs *regionStore) client(region string) *aws.MockSSMClient {
+	return &aws.MockSSMClient{
+		GetParamFunc: func(ctx context.Context, input *ssm.GetParameterInput, opts ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+			rs.mu.Lock()
+			defer rs.mu.Unlock()
+			p, ok := rs.params[region][*input.Name]
+			if !ok {
+				return nil, &types.ParameterNotFound{}
+			}
+			return &ssm.GetParameterOutput{Parameter: &types.Parameter{
+				Name: &p.Name, Value: &p.Value, Type: types.ParameterType(p.Type), Version: p.Version,
+			}}, nil
+		},
+		GetParamsFunc: func(ctx context.Context, input *ssm.GetParametersInput, opts ...func(*ssm.Options)) (*ssm.GetParametersOutput, error) {
+			rs.mu.Lock()
+			defer rs.mu.Unlock()
+			output := &ssm.GetParametersOutput{}
+			for _, name := range input.Names {
+				p, ok := rs.params[region][name]
+				if !ok {
+					output.InvalidParameters = append(output.InvalidParameters, name)
+					continue
+				}
+				output.Parameters = append(output.Parameters, types.Parameter{
+					Name: strPtr(p.Name), Value: strPtr(p.Value), Type: types.ParameterType(p.Type), Version: p.Version,
+				})
+			}
+			return output, nil
+		},
+		GetByPathFunc: func(ctx context.Context, input *ssm.GetParametersByPathInput, opts ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+			rs.mu.Lock()
+			defer rs.mu.Unlock()
+			output := &ssm.GetParametersByPathOutput{}
+			for _, p := range rs.params[region] {
+				if strings.HasPrefix(p.Name, *input.Path+"/") {
+					output.Parameters = append(output.Parameters, types.Parameter{
+						Name: strPtr(p.Name), Value: strPtr(p.Value), Type: types.ParameterType(p.Type), Version: p.Version,
+					})
+				}
+			}
+			return output, nil
+		},
+		DescribeFunc: func(ctx context.Context, input *ssm.DescribeParametersInput, opts ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error) {
+			rs.mu.Lock()
+			defer rs.mu.Unlock()
+			output := &ssm.DescribeParametersOutput{}
+			for _, p := range rs.params[region] {
+				if !matchesFilters(p.Name, input.ParameterFilters) {
+					continue
+				}
+				output.Parameters = append(output.Parameters, types.ParameterMetadata{
+					Name: strPtr(p.Name), Description: strPtr(p.Description), KeyId: strPtr(p.KeyID),
+					Type: types.ParameterType(p.Type), Version: p.Version, Tier: types.ParameterTier(p.Tier),
+				})
+			}
+			return output, nil
+		},
+		PutParamFunc: func(ctx context.Context, input *ssm.PutParameterInput, opts ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+			rs.mu.Lock()
+			defer rs.mu.Unlock()
+			if rs.failWrites[region] {
+				return nil, fmt.Errorf("write failed in %s", region)
+			}
+			if rs.params[region] == nil {
+				rs.params[region] = make(map[string]aws.Parameter)
+			}
+			p, exists := rs.params[region][*input.Name]
+			if exists && (input.Overwrite == nil || !*input.Overwrite) {
+				return nil, &types.ParameterAlreadyExists{}
+			}
+			p.Name = *input.Name
+			p.Value = *input.Value
+			if input.Type != "" {
+				p.Type = string(input.Type)
+			}
+			// Like SSM, an empty description doesn't clear the current one
+			if input.Description != nil && *input.Description != "" {
+				p.Description = *input.Description
+			}
+			if input.KeyId != nil {
+				p.KeyID = *input.KeyId
+			}
+			if input.Tier != "" {
+				p.Tier = string(input.Tier)
+			}
+			p.Version++
+			rs.params[region][p.Name] = p
+			rs.puts[region] = append(rs.puts[region], p.Name)
+			return &ssm.PutParameterOutput{Version: p.Version}, nil
+		},
+		DeleteParamFunc: func(ctx context.Context, input *ssm.DeleteParameterInput, opts ...func(*ssm.Options)) (*ssm.DeleteParameterOutput, error) {
+			rs.mu.Lock()
+			defer rs.mu.Unlock()
+			if rs.failWrites[region] {
+				return nil, fmt.Errorf("delete failed in %s", region)
+			}
+			if _, ok := rs.params[region][*input.Name]; !ok {
+				return nil, &types.ParameterNotFound{}
+			}
+			delete(rs.params[region], *input.Name)
+			delete(rs.tags[region], *input.Name)
+			return &ssm.DeleteParameterOutput{}, nil
+		},
+		DeleteParamsFunc: func(ctx context.Context, input *ssm.DeleteParametersInput, opts ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error) {
+			rs.mu.Lock()
+			defer rs.mu.Unlock()
+			if rs.failWrites[region] {
+				return nil, fmt.Errorf("delete failed in %s", region)
+			}
+			output := &ssm.DeleteParametersOutput{}
+			for _, name := range input.Names {
+				if _, ok := rs.params[region][name]; !ok {
+					output.InvalidParameters = append(output.InvalidParameters, name)
+					continue
+				}
+				delete(rs.params[region], name)
+				delete(rs.tags[region], name)
+				output.DeletedParameters = append(output.DeletedParameters, name)
+			}
+			return output, nil
+		},
+		ListTagsFunc: func(ctx context.Context, input *ssm.ListTagsForResourceInput, opts ...func(*ssm.Options)) (*ssm.ListTagsForResourceOutput, error) {
+			rs.mu.Lock()
+			defer rs.mu.Unlock()
+			output := &ssm.ListTagsForResourceOutput{}
+			for key, value := range rs.tags[region][*input.ResourceId] {
+				output.TagList = append(output.TagList, types.Tag{Key: strPtr(key), Value: strPtr(value)})
+			}
+			return output, nil
+		},
+		AddTagsFunc: func(ctx context.Context, input *ssm.AddTagsToResourceInput, opts ...func(*ssm.Options)) (*ssm.AddTagsToResourceOutput, error) {
+			rs.mu.Lock()
+			defer rs.mu.Unlock()
+			if rs.tags[region] == nil {
+				rs.tags[region] = make(map[string]map[string]string)
+			}
+			if rs.tags[region][*input.ResourceId] == nil {
+				rs.tags[region][*input.ResourceId] = make(map[string]string)
+			}
+			for _, tag := range input.Tags {
+				rs.tags[region][*input.ResourceId][*tag.Key] = *tag.Value
+			}
+			return &ssm.AddTagsToResourceOutput{}, nil
+		},
+	}
+}
+
+// matchesFilters reports whether a parameter name matches the Name and Path
+// filters of a DescribeParameters call
+func matchesFilters(name string, filters []types.ParameterStringFilter) bool {
+	for _, filter := range filters {
+		for _, value := range filter.Values {
+			switch *filter.Key {
+			case "Name":
+				if name != value {
+					return false
+				}
+			case "Path":
+				if !strings.HasPrefix(name, value+"/") {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// install replaces aws.NewClient with clients backed by the region store
+func (rs *regionStore) install() {
+	aws.NewClient = func(ctx context.Context, region, role string) (*aws.Client, error) {
+		return &aws.Client{SSMClient: rs.client(region)}, nil
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func setupVerifyFlags() {
+	verifyCmd.ResetFlags()
+	verifyCmd.Flags().StringVar(&verifyPath, "path", "", "Parameter path to verify")
+	verifyCmd.Flags().StringVar(&verifyPrefix, "prefix", "", "Parameter path prefix to verify")
+	verifyCmd.Flags().StringVar(&verifyRegion, "region", "", "AWS region")
+	verifyCmd.Flags().StringVar(&verifyRole, "role", "", "AWS role ARN")
+	verifyCmd.Flags().StringSliceVar(&verifyReplicas, "replica", nil, "Replica regions")
+	verifyCmd.Flags().BoolVar(&verifyFix, "fix", false, "Fix drift")
+	testRoot.AddCommand(verifyCmd)
+}
+
+func TestCompareParameters(t *testing.T) {
+	base := aws.Parameter{Name: "/app/a", Value: "secret", Type: aws.ParameterTypeString, Description: "desc"}
+
+	tests := []struct {
+		name    string
+		replica aws.Parameter
+		want    int
+	}{
+		{"in_sync", base, 0},
+		{"value_differs", aws.Parameter{Name: "/app/a", Value: "other", Type: aws.ParameterTypeString, Description: "desc"}, 1},
+		{"type_and_description_differ", aws.Parameter{Name: "/app/a", Value: "secret", Type: aws.ParameterTypeSecureString}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := compareParameters(base, tt.replica)
+			if len(reasons) != tt.want {
+				t.Errorf("compareParameters() = %v, want %d reason(s)", reasons, tt.want)
+			}
+			for _, reason := range reasons {
+				if strings.Contains(reason, "secret") || strings.Contains(reason, "other") {
+					t.Errorf("compareParameters() reason %q leaks the parameter value", reason)
+				}
+				for _, value := range []string{tt.replica.Value, base.Value} {
+					if strings.Contains(reason, hashValue(value)[:8]) {
+						t.Errorf("compareParameters() reason %q leaks a hash of the parameter value", reason)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRunVerify(t *testing.T) {
+	ts := setupTest(t)
+	defer ts.cleanup()
+
+	inSync := func() map[string]map[string]aws.Parameter {
+		return map[string]map[string]aws.Parameter{
+			"us-west-2": {
+				"/app/a": {Name: "/app/a", Value: "a", Type: aws.ParameterTypeString},
+				"/app/b": {Name: "/app/b", Value: "b", Type: aws.ParameterTypeSecureString, KeyID: "alias/app"},
+			},
+			"eu-west-1": {
+				"/app/a": {Name: "/app/a", Value: "a", Type: aws.ParameterTypeString},
+				"/app/b": {Name: "/app/b", Value: "b", Type: aws.ParameterTypeSecureString, KeyID: "alias/app"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		modify   func(map[string]map[string]aws.Parameter)
+		dryRun   bool
+		wantErr  bool
+		wantPuts int
+	}{
+		{
+			name: "subtree_in_sync",
+			args: []string{"--prefix", "/app"},
+		},
+		{
+			name: "single_parameter_in_sync",
+			args: []string{"--path", "/app/a"},
+		},
+		{
+			name: "value_drift",
+			args: []string{"--prefix", "/app"},
+			modify: func(p map[string]map[string]aws.Parameter) {
+				p["eu-west-1"]["/app/a"] = aws.Parameter{Name: "/app/a", Value: "changed", Type: aws.ParameterTypeString}
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing_in_replica_fixed",
+			args: []string{"--prefix", "/app", "--fix"},
+			modify: func(p map[string]map[string]aws.Parameter) {
+				delete(p["eu-west-1"], "/app/b")
+			},
+			wantPuts: 1,
+		},
+		{
+			name: "dry_run_fix_not_written",
+			args: []string{"--prefix", "/app", "--fix"},
+			modify: func(p map[string]map[string]aws.Parameter) {
+				delete(p["eu-west-1"], "/app/b")
+				p["eu-west-1"]["/app/a"] = aws.Parameter{Name: "/app/a", Value: "changed", Type: aws.ParameterTypeSecureString}
+			},
+			dryRun: true,
+		},
+		{
+			name: "cleared_description_not_fixed",
+			args: []string{"--prefix", "/app", "--fix"},
+			modify: func(p map[string]map[string]aws.Parameter) {
+				p["eu-west-1"]["/app/a"] = aws.Parameter{Name: "/app/a", Value: "a", Type: aws.ParameterTypeString, Description: "old"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "cleared_description_value_fixed",
+			args: []string{"--prefix", "/app", "--fix"},
+			modify: func(p map[string]map[string]aws.Parameter) {
+				p["eu-west-1"]["/app/a"] = aws.Parameter{Name: "/app/a", Value: "changed", Type: aws.ParameterTypeString, Description: "old"}
+			},
+			wantErr:  true,
+			wantPuts: 1,
+		},
+		{
+			name: "dry_run_cleared_description",
+			args: []string{"--prefix", "/app", "--fix"},
+			modify: func(p map[string]map[string]aws.Parameter) {
+				p["eu-west-1"]["/app/a"] = aws.Parameter{Name: "/app/a", Value: "a", Type: aws.ParameterTypeString, Description: "old"}
+			},
+			dryRun:  true,
+			wantErr: true,
+		},
+		{
+			name: "extra_in_replica_not_fixed",
+			args: []string{"--prefix", "/app", "--fix"},
+			modify: func(p map[string]map[string]aws.Parameter) {
+				p["eu-west-1"]["/app/c"] = aws.Parameter{Name: "/app/c", Value: "c", Type: aws.ParameterTypeString}
+			},
+			wantErr: true,
+		},
+		{
+			name:    "primary_parameter_not_found",
+			args:    []string{"--path", "/app/missing"},
+			wantErr: true,
+		},
+		{
+			name:    "path_and_prefix",
+			args:    []string{"--path", "/app/a", "--prefix", "/app"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := inSync()
+			if tt.modify != nil {
+				tt.modify(params)
+			}
+			store := newRegionStore(params)
+			store.install()
+
+			setupVerifyFlags()
+			setupDryRunFlag()
+			defer func() { dryRun = false }()
+			args := []string{"verify", "--region", "us-west-2", "--replica", "eu-west-1"}
+			if tt.dryRun {
+				args = append([]string{"--dry-run"}, args...)
+			}
+			testRoot.SetArgs(append(args, tt.args...))
+			err := testRoot.Execute()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runVerify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(store.puts["eu-west-1"]); got != tt.wantPuts {
+				t.Errorf("runVerify() wrote %d parameter(s) to the replica, want %d", got, tt.wantPuts)
+			}
+		})
+	}
+}
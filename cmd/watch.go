@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timing of the watch mode
+var (
+	// watchJitter is the maximum share of the interval that is randomly
+	// added, so many hosts don't hit the API at the same time
+	watchJitter = 0.1
+	// watchMinBackoff is the first delay after a failed read, it doubles
+	// with every further failure up to the interval
+	watchMinBackoff = 5 * time.Second
+)
+
+// watch runs readOnce, then again every interval until ctx is done. Changes
+// are announced with notifyChange. A failed read is retried with
+// exponential backoff, only the first read has to succeed.
+//
+// Parameters:
+//   - ctx: Stops watching when done
+//   - interval: The time between two reads
+//   - readOnce: Reads and writes the parameters, returns if the output changed
+//
+// Returns:
+//   - An error if the first read fails
+func watch(ctx context.Context, interval time.Duration, readOnce func() (bool, error)) error {
+	backoff := time.Duration(0)
+	for first := true; ; first = false {
+		changed, err := readOnce()
+		delay := withJitter(interval)
+		switch {
+		case err != nil && first:
+			return err
+		case err != nil:
+			backoff = nextBackoff(backoff, interval)
+			delay = backoff
+			slog.Error("Failed to read parameters", "error", err, "retry_in", delay)
+		default:
+			backoff = 0
+			if changed {
+				if err := notifyChange(); err != nil {
+					slog.Error("Failed to notify about changed parameters", "error", err)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("Stopped watching parameters")
+			return nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+// withJitter adds up to watchJitter of the interval randomly
+func withJitter(interval time.Duration) time.Duration {
+	return interval + time.Duration(rand.Float64()*watchJitter*float64(interval))
+}
+
+// nextBackoff doubles the backoff, starting at watchMinBackoff and limited
+// to the interval
+func nextBackoff(backoff, interval time.Duration) time.Duration {
+	return min(max(backoff*2, watchMinBackoff), interval)
+}
+
+// notifyChange runs the on-change command and sends the reload signal, if
+// configured
+func notifyChange() error {
+	if readOnChange != "" {
+		if err := runHook(readOnChange); err != nil {
+			return fmt.Errorf("on-change command failed: %w", err)
+		}
+	}
+	if readSignal == "" {
+		return nil
+	}
+
+	sig, err := parseSignal(readSignal)
+	if err != nil {
+		return err
+	}
+	pid := readPID
+	if readPIDFile != "" {
+		data, err := os.ReadFile(readPIDFile)
+		if err != nil {
+			return fmt.Errorf("failed to read PID file: %w", err)
+		}
+		if pid, err = strconv.Atoi(strings.TrimSpace(string(data))); err != nil || pid <= 0 {
+			return fmt.Errorf("invalid PID in %s", readPIDFile)
+		}
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return fmt.Errorf("failed to find process %d: %w", pid, err)
+	}
+	if err := process.Signal(sig); err != nil {
+		return fmt.Errorf("failed to send %s to process %d: %w", readSignal, pid, err)
+	}
+	fmt.Printf("Sent %s to process %d\n", readSignal, pid)
+	return nil
+}
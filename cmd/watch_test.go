@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestWatch(t *testing.T) {
+	origBackoff := watchMinBackoff
+	watchMinBackoff = time.Millisecond
+	defer func() {
+		watchMinBackoff = origBackoff
+		readOnChange = ""
+	}()
+
+	marker := filepath.Join(t.TempDir(), "changed")
+	readOnChange = "echo x >> " + marker
+
+	// changed, failed, unchanged, changed, then stop
+	results := []struct {
+		changed bool
+		err     error
+	}{
+		{changed: true},
+		{err: errors.New("throttled")},
+		{changed: false},
+		{changed: true},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	readOnce := func() (bool, error) {
+		r := results[calls]
+		calls++
+		if calls == len(results) {
+			cancel()
+		}
+		return r.changed, r.err
+	}
+
+	if err := watch(ctx, time.Millisecond, readOnce); err != nil {
+		t.Fatalf("watch() error = %v", err)
+	}
+	if calls != len(results) {
+		t.Errorf("watch() read %d times, want %d", calls, len(results))
+	}
+	content, err := os.ReadFile(marker)
+	if err != nil {
+		t.Fatalf("Failed to read marker file: %v", err)
+	}
+	if got := strings.Count(string(content), "x"); got != 2 {
+		t.Errorf("on-change ran %d times, want 2", got)
+	}
+}
+
+func TestWatchFirstReadFails(t *testing.T) {
+	readOnce := func() (bool, error) { return false, errors.New("access denied") }
+	if err := watch(context.Background(), time.Hour, readOnce); err == nil || err.Error() != "access denied" {
+		t.Errorf("watch() error = %v, want the error of the first read", err)
+	}
+}
+
+func TestNotifyChangeSignal(t *testing.T) {
+	sig, err := parseSignal("USR1")
+	if err != nil {
+		t.Skipf("Signals not supported: %v", err)
+	}
+	defer func() { readSignal, readPID, readPIDFile = "", 0, "" }()
+
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, sig)
+	defer signal.Stop(received)
+
+	pidFile := filepath.Join(t.TempDir(), "app.pid")
+	if err := os.WriteFile(pidFile, []byte(strconv.Itoa(os.Getpid())+"\n"), 0600); err != nil {
+		t.Fatalf("Failed to write PID file: %v", err)
+	}
+	readSignal, readPIDFile = "SIGUSR1", pidFile
+
+	if err := notifyChange(); err != nil {
+		t.Fatalf("notifyChange() error = %v", err)
+	}
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("signal not received")
+	}
+
+	readPIDFile = filepath.Join(t.TempDir(), "missing.pid")
+	if err := notifyChange(); err == nil || !strings.Contains(err.Error(), "failed to read PID file") {
+		t.Errorf("notifyChange() error = %v, want missing PID file error", err)
+	}
+}
+
+func TestParseSignal(t *testing.T) {
+	if _, err := parseSignal("HUP"); err != nil {
+		t.Skipf("Signals not supported: %v", err)
+	}
+	for _, name := range []string{"HUP", "sighup", "SIGHUP", "1"} {
+		if sig, err := parseSignal(name); err != nil || sig != 1 {
+			t.Errorf("parseSignal(%q) = %v, %v, want SIGHUP", name, sig, err)
+		}
+	}
+	if _, err := parseSignal("NOPE"); err == nil {
+		t.Error("parseSignal(\"NOPE\") error = nil, want error")
+	}
+}
+
+func TestRunReadWatchFlags(t *testing.T) {
+	rts := setupReadTest(t)
+	defer rts.cleanup()
+	defer func() {
+		readSignal, readPID, readPIDFile = "", 0, ""
+		readWatch, readInterval, readChangedExitCode = false, 5*time.Minute, 0
+	}()
+
+	tests := []struct {
+		name   string
+		args   []string
+		errMsg string
+	}{
+		{name: "signal_without_pid", args: []string{"--signal", "HUP"}, errMsg: "requires either"},
+		{name: "signal_with_pid_and_pid_file", args: []string{"--signal", "HUP", "--pid", "1", "--pid-file", "x"}, errMsg: "requires either"},
+		{name: "pid_without_signal", args: []string{"--pid", "1"}, errMsg: "require \"signal\""},
+		{name: "invalid_interval", args: []string{"--watch", "--interval", "0s"}, errMsg: "invalid interval"},
+		{name: "watch_with_exit_code", args: []string{"--watch", "--changed-exit-code", "3"}, errMsg: "mutually exclusive"},
+		{name: "watch_without_file", args: []string{"--watch"}, errMsg: "require a file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testRoot := &cobra.Command{Use: "params2env"}
+			setupReadFlags(t, testRoot)
+			testRoot.SetArgs(append([]string{"read", "--path", "/app/url"}, tt.args...))
+			if err := testRoot.Execute(); err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("runRead() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
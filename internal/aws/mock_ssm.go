@@ -13,9 +13,15 @@ import (
 
 // MockSSMClient implements SSMAPI for testing
 type MockSSMClient struct {
-	GetParamFunc    func(context.Context, *ssm.GetParameterInput, ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
-	PutParamFunc    func(context.Context, *ssm.PutParameterInput, ...func(*ssm.Options)) (*ssm.PutParameterOutput, error)
-	DeleteParamFunc func(context.Context, *ssm.DeleteParameterInput, ...func(*ssm.Options)) (*ssm.DeleteParameterOutput, error)
+	GetParamFunc     func(context.Context, *ssm.GetParameterInput, ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+	GetParamsFunc    func(context.Context, *ssm.GetParametersInput, ...func(*ssm.Options)) (*ssm.GetParametersOutput, error)
+	PutParamFunc     func(context.Context, *ssm.PutParameterInput, ...func(*ssm.Options)) (*ssm.PutParameterOutput, error)
+	DeleteParamFunc  func(context.Context, *ssm.DeleteParameterInput, ...func(*ssm.Options)) (*ssm.DeleteParameterOutput, error)
+	DeleteParamsFunc func(context.Context, *ssm.DeleteParametersInput, ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error)
+	DescribeFunc     func(context.Context, *ssm.DescribeParametersInput, ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error)
+	GetByPathFunc    func(context.Context, *ssm.GetParametersByPathInput, ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error)
+	ListTagsFunc     func(context.Context, *ssm.ListTagsForResourceInput, ...func(*ssm.Options)) (*ssm.ListTagsForResourceOutput, error)
+	AddTagsFunc      func(context.Context, *ssm.AddTagsToResourceInput, ...func(*ssm.Options)) (*ssm.AddTagsToResourceOutput, error)
 }
 
 func (m *MockSSMClient) GetParameter(ctx context.Context, input *ssm.GetParameterInput, opts ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
@@ -25,6 +31,13 @@ func (m *MockSSMClient) GetParameter(ctx context.Context, input *ssm.GetParamete
 	return nil, fmt.Errorf("GetParameter not implemented")
 }
 
+func (m *MockSSMClient) GetParameters(ctx context.Context, input *ssm.GetParametersInput, opts ...func(*ssm.Options)) (*ssm.GetParametersOutput, error) {
+	if m.GetParamsFunc != nil {
+		return m.GetParamsFunc(ctx, input, opts...)
+	}
+	return nil, fmt.Errorf("GetParameters not implemented")
+}
+
 func (m *MockSSMClient) PutParameter(ctx context.Context, input *ssm.PutParameterInput, opts ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
 	if m.PutParamFunc != nil {
 		return m.PutParamFunc(ctx, input, opts...)
@@ -38,3 +51,38 @@ func (m *MockSSMClient) DeleteParameter(ctx context.Context, input *ssm.DeletePa
 	}
 	return nil, fmt.Errorf("DeleteParameter not implemented")
 }
+
+func (m *MockSSMClient) DeleteParameters(ctx context.Context, input *ssm.DeleteParametersInput, opts ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error) {
+	if m.DeleteParamsFunc != nil {
+		return m.DeleteParamsFunc(ctx, input, opts...)
+	}
+	return nil, fmt.Errorf("DeleteParameters not implemented")
+}
+
+func (m *MockSSMClient) DescribeParameters(ctx context.Context, input *ssm.DescribeParametersInput, opts ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error) {
+	if m.DescribeFunc != nil {
+		return m.DescribeFunc(ctx, input, opts...)
+	}
+	return nil, fmt.Errorf("DescribeParameters not implemented")
+}
+
+func (m *MockSSMClient) GetParametersByPath(ctx context.Context, input *ssm.GetParametersByPathInput, opts ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+	if m.GetByPathFunc != nil {
+		return m.GetByPathFunc(ctx, input, opts...)
+	}
+	return nil, fmt.Errorf("GetParametersByPath not implemented")
+}
+
+func (m *MockSSMClient) ListTagsForResource(ctx context.Context, input *ssm.ListTagsForResourceInput, opts ...func(*ssm.Options)) (*ssm.ListTagsForResourceOutput, error) {
+	if m.ListTagsFunc != nil {
+		return m.ListTagsFunc(ctx, input, opts...)
+	}
+	return nil, fmt.Errorf("ListTagsForResource not implemented")
+}
+
+func (m *MockSSMClient) AddTagsToResource(ctx context.Context, input *ssm.AddTagsToResourceInput, opts ...func(*ssm.Options)) (*ssm.AddTagsToResourceOutput, error) {
+	if m.AddTagsFunc != nil {
+		return m.AddTagsFunc(ctx, input, opts...)
+	}
+	return nil, fmt.Errorf("AddTagsToResource not implemented")
+}
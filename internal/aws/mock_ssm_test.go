@@ -17,6 +17,14 @@ func TestMockSSMClientGetParameterWithoutFunction(t *testing.T) {
 	}
 }
 
+func TestMockSSMClientGetParametersWithoutFunction(t *testing.T) {
+	mock := &MockSSMClient{}
+	_, err := mock.GetParameters(context.Background(), nil)
+	if err == nil {
+		t.Error("MockSSMClient.GetParameters() expected error, got nil")
+	}
+}
+
 func TestMockSSMClientPutParameterWithoutFunction(t *testing.T) {
 	mock := &MockSSMClient{}
 	_, err := mock.PutParameter(context.Background(), nil)
@@ -32,3 +40,43 @@ func TestMockSSMClientDeleteParameterWithoutFunction(t *testing.T) {
 		t.Error("MockSSMClient.DeleteParameter() expected error, got nil")
 	}
 }
+
+func TestMockSSMClientDeleteParametersWithoutFunction(t *testing.T) {
+	mock := &MockSSMClient{}
+	_, err := mock.DeleteParameters(context.Background(), nil)
+	if err == nil {
+		t.Error("MockSSMClient.DeleteParameters() expected error, got nil")
+	}
+}
+
+func TestMockSSMClientDescribeParametersWithoutFunction(t *testing.T) {
+	mock := &MockSSMClient{}
+	_, err := mock.DescribeParameters(context.Background(), nil)
+	if err == nil {
+		t.Error("MockSSMClient.DescribeParameters() expected error, got nil")
+	}
+}
+
+func TestMockSSMClientGetParametersByPathWithoutFunction(t *testing.T) {
+	mock := &MockSSMClient{}
+	_, err := mock.GetParametersByPath(context.Background(), nil)
+	if err == nil {
+		t.Error("MockSSMClient.GetParametersByPath() expected error, got nil")
+	}
+}
+
+func TestMockSSMClientListTagsForResourceWithoutFunction(t *testing.T) {
+	mock := &MockSSMClient{}
+	_, err := mock.ListTagsForResource(context.Background(), nil)
+	if err == nil {
+		t.Error("MockSSMClient.ListTagsForResource() expected error, got nil")
+	}
+}
+
+func TestMockSSMClientAddTagsToResourceWithoutFunction(t *testing.T) {
+	mock := &MockSSMClient{}
+	_, err := mock.AddTagsToResource(context.Background(), nil)
+	if err == nil {
+		t.Error("MockSSMClient.AddTagsToResource() expected error, got nil")
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -42,21 +44,105 @@ var (
 	ErrParameterExists = errors.New("parameter already exists")
 	ErrNoAccess        = errors.New("insufficient permissions")
 	ErrNotFound        = errors.New("parameter not found")
+	ErrVersionConflict = errors.New("parameter version conflict")
 )
 
 // Valid parameter types as defined by AWS SSM
 const (
 	ParameterTypeString       = "String"
+	ParameterTypeStringList   = "StringList"
 	ParameterTypeSecureString = "SecureString"
 )
 
+// Parameter tiers as defined by AWS SSM
+const (
+	ParameterTierStandard           = "Standard"
+	ParameterTierAdvanced           = "Advanced"
+	ParameterTierIntelligentTiering = "Intelligent-Tiering"
+)
+
+// Maximum value sizes in bytes per parameter tier
+const (
+	MaxStandardValueSize = 4096
+	MaxAdvancedValueSize = 8192
+)
+
+// maxDeleteBatchSize is the maximum number of parameters SSM deletes in a
+// single DeleteParameters call
+const maxDeleteBatchSize = 10
+
+// maxGetBatchSize is the maximum number of parameters SSM returns in a
+// single GetParameters call
+const maxGetBatchSize = 10
+
+// Parameter holds a parameter value together with the metadata
+// returned by SSM Parameter Store.
+type Parameter struct {
+	// Name is the full path of the parameter
+	Name string
+	// Value is the parameter value, decrypted for SecureString parameters
+	Value string
+	// Type is the parameter type (String, StringList or SecureString)
+	Type string
+	// Version is the parameter version, incremented on every change
+	Version int64
+	// Description is the parameter description, only set by
+	// DescribeParameter and ListParameters
+	Description string
+	// KeyID is the KMS key used to encrypt a SecureString parameter,
+	// only set by DescribeParameter and ListParameters
+	KeyID string
+	// Tier is the parameter tier (Standard or Advanced), only set by
+	// DescribeParameter and ListParameters
+	Tier string
+}
+
+// IsValidParameterType reports whether paramType is one of the
+// parameter types supported by SSM Parameter Store.
+func IsValidParameterType(paramType string) bool {
+	switch paramType {
+	case ParameterTypeString, ParameterTypeStringList, ParameterTypeSecureString:
+		return true
+	}
+	return false
+}
+
+// IsValidParameterTier reports whether tier is one of the parameter
+// tiers supported by SSM Parameter Store.
+func IsValidParameterTier(tier string) bool {
+	switch tier {
+	case ParameterTierStandard, ParameterTierAdvanced, ParameterTierIntelligentTiering:
+		return true
+	}
+	return false
+}
+
+// PutOption sets optional fields of a PutParameter call made by
+// CreateParameter and ModifyParameter
+type PutOption func(*ssm.PutParameterInput)
+
+// WithTier sets the tier of the parameter, an empty tier keeps the default
+func WithTier(tier string) PutOption {
+	return func(input *ssm.PutParameterInput) {
+		if tier != "" {
+			input.Tier = ssmtypes.ParameterTier(tier)
+		}
+	}
+}
+
 // SSMAPI defines the interface for AWS SSM operations.
 // This interface allows for easy mocking in tests and flexibility
 // in implementation.
 type SSMAPI interface {
 	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+	GetParameters(ctx context.Context, params *ssm.GetParametersInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersOutput, error)
 	PutParameter(ctx context.Context, params *ssm.PutParameterInput, optFns ...func(*ssm.Options)) (*ssm.PutParameterOutput, error)
 	DeleteParameter(ctx context.Context, params *ssm.DeleteParameterInput, optFns ...func(*ssm.Options)) (*ssm.DeleteParameterOutput, error)
+	DeleteParameters(ctx context.Context, params *ssm.DeleteParametersInput, optFns ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error)
+	DescribeParameters(ctx context.Context, params *ssm.DescribeParametersInput, optFns ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error)
+	GetParametersByPath(ctx context.Context, params *ssm.GetParametersByPathInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error)
+	ListTagsForResource(ctx context.Context, params *ssm.ListTagsForResourceInput, optFns ...func(*ssm.Options)) (*ssm.ListTagsForResourceOutput, error)
+	AddTagsToResource(ctx context.Context, params *ssm.AddTagsToResourceInput, optFns ...func(*ssm.Options)) (*ssm.AddTagsToResourceOutput, error)
 }
 
 // Client represents an AWS SSM client with the necessary API operations.
@@ -112,8 +198,28 @@ var NewClient = DefaultNewClient
 //   - ErrNotFound if the parameter doesn't exist
 //   - ErrNoAccess if there are insufficient permissions
 func (c *Client) GetParameter(ctx context.Context, name string) (string, error) {
+	param, err := c.GetParameterInfo(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return param.Value, nil
+}
+
+// GetParameterInfo retrieves a parameter from SSM Parameter Store including
+// its type and version. SecureString parameters are decrypted.
+//
+// Parameters:
+//   - ctx: Context for the AWS API call
+//   - name: The full path of the parameter to retrieve
+//
+// Returns:
+//   - The parameter with value, type and version
+//   - ErrEmptyName if name is empty
+//   - ErrNotFound if the parameter doesn't exist
+//   - ErrNoAccess if there are insufficient permissions
+func (c *Client) GetParameterInfo(ctx context.Context, name string) (*Parameter, error) {
 	if name == "" {
-		return "", ErrEmptyName
+		return nil, ErrEmptyName
 	}
 
 	withDecryption := true
@@ -126,22 +232,255 @@ func (c *Client) GetParameter(ctx context.Context, name string) (string, error)
 	if err != nil {
 		var pnf *ssmtypes.ParameterNotFound
 		if errors.As(err, &pnf) {
-			return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
 		}
 		var ae smithy.APIError
 		if errors.As(err, &ae) {
 			if ae.ErrorCode() == "AccessDeniedException" {
-				return "", fmt.Errorf("%w to get parameter %s", ErrNoAccess, name)
+				return nil, fmt.Errorf("%w to get parameter %s", ErrNoAccess, name)
 			}
 		}
-		return "", fmt.Errorf("failed to get parameter %s: %w", name, err)
+		return nil, fmt.Errorf("failed to get parameter %s: %w", name, err)
 	}
 
 	if output.Parameter == nil || output.Parameter.Value == nil {
-		return "", fmt.Errorf("parameter %s has no value", name)
+		return nil, fmt.Errorf("parameter %s has no value", name)
+	}
+
+	return &Parameter{
+		Name:    name,
+		Value:   *output.Parameter.Value,
+		Type:    string(output.Parameter.Type),
+		Version: output.Parameter.Version,
+	}, nil
+}
+
+// GetParameters retrieves multiple parameters from SSM Parameter Store in
+// batches of 10. SecureString parameters are decrypted.
+//
+// Parameters:
+//   - ctx: Context for the AWS API call
+//   - names: The full paths of the parameters to retrieve
+//
+// Returns:
+//   - The parameters with value, type and version, keyed by name
+//   - ErrEmptyName if a name is empty
+//   - ErrNotFound if a parameter doesn't exist
+//   - ErrNoAccess if there are insufficient permissions
+func (c *Client) GetParameters(ctx context.Context, names []string) (map[string]Parameter, error) {
+	for _, name := range names {
+		if name == "" {
+			return nil, ErrEmptyName
+		}
+	}
+
+	params := make(map[string]Parameter, len(names))
+	withDecryption := true
+	for start := 0; start < len(names); start += maxGetBatchSize {
+		input := &ssm.GetParametersInput{
+			Names:          names[start:min(start+maxGetBatchSize, len(names))],
+			WithDecryption: &withDecryption,
+		}
+
+		output, err := c.SSMClient.GetParameters(ctx, input)
+		if err != nil {
+			var ae smithy.APIError
+			if errors.As(err, &ae) {
+				if ae.ErrorCode() == "AccessDeniedException" {
+					return nil, fmt.Errorf("%w to get parameters", ErrNoAccess)
+				}
+			}
+			return nil, fmt.Errorf("failed to get parameters: %w", err)
+		}
+		if len(output.InvalidParameters) > 0 {
+			sort.Strings(output.InvalidParameters)
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, strings.Join(output.InvalidParameters, ", "))
+		}
+
+		for _, p := range output.Parameters {
+			name := aws.ToString(p.Name)
+			params[name] = Parameter{
+				Name:    name,
+				Value:   aws.ToString(p.Value),
+				Type:    string(p.Type),
+				Version: p.Version,
+			}
+		}
+	}
+
+	return params, nil
+}
+
+// DescribeParameter retrieves a parameter including its metadata like
+// description and KMS key. SecureString parameters are decrypted.
+//
+// Parameters:
+//   - ctx: Context for the AWS API call
+//   - name: The full path of the parameter to retrieve
+//
+// Returns:
+//   - The parameter with value, type, version, description and KMS key
+//   - ErrEmptyName if name is empty
+//   - ErrNotFound if the parameter doesn't exist
+//   - ErrNoAccess if there are insufficient permissions
+func (c *Client) DescribeParameter(ctx context.Context, name string) (*Parameter, error) {
+	param, err := c.GetParameterInfo(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	filterKey := "Name"
+	filterOption := "Equals"
+	metadata, err := c.describeParameters(ctx, []ssmtypes.ParameterStringFilter{
+		{Key: &filterKey, Option: &filterOption, Values: []string{name}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if m, ok := metadata[name]; ok {
+		applyMetadata(param, m)
+	}
+	return param, nil
+}
+
+// ListParameters retrieves all parameters below the given path, recursively,
+// including their metadata. SecureString parameters are decrypted. The result
+// is sorted by parameter name.
+//
+// Parameters:
+//   - ctx: Context for the AWS API call
+//   - path: The path prefix, e.g. /myapp
+//
+// Returns:
+//   - The parameters below path, empty if there are none
+//   - ErrEmptyName if path is empty
+//   - ErrNoAccess if there are insufficient permissions
+func (c *Client) ListParameters(ctx context.Context, path string) ([]Parameter, error) {
+	if path == "" {
+		return nil, ErrEmptyName
+	}
+
+	var params []Parameter
+	recursive := true
+	withDecryption := true
+	input := &ssm.GetParametersByPathInput{
+		Path:           &path,
+		Recursive:      &recursive,
+		WithDecryption: &withDecryption,
+	}
+	for {
+		output, err := c.SSMClient.GetParametersByPath(ctx, input)
+		if err != nil {
+			var ae smithy.APIError
+			if errors.As(err, &ae) {
+				if ae.ErrorCode() == "AccessDeniedException" {
+					return nil, fmt.Errorf("%w to list parameters below %s", ErrNoAccess, path)
+				}
+			}
+			return nil, fmt.Errorf("failed to list parameters below %s: %w", path, err)
+		}
+		for _, p := range output.Parameters {
+			params = append(params, Parameter{
+				Name:    aws.ToString(p.Name),
+				Value:   aws.ToString(p.Value),
+				Type:    string(p.Type),
+				Version: p.Version,
+			})
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	if len(params) == 0 {
+		return params, nil
 	}
 
-	return *output.Parameter.Value, nil
+	filterKey := "Path"
+	filterOption := "Recursive"
+	metadata, err := c.describeParameters(ctx, []ssmtypes.ParameterStringFilter{
+		{Key: &filterKey, Option: &filterOption, Values: []string{path}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range params {
+		if m, ok := metadata[params[i].Name]; ok {
+			applyMetadata(&params[i], m)
+		}
+	}
+
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+	return params, nil
+}
+
+// ListParameterNames retrieves the names of all parameters below the given
+// path, recursively, without reading their values. The result is sorted.
+//
+// Parameters:
+//   - ctx: Context for the AWS API call
+//   - path: The path prefix, e.g. /myapp
+//
+// Returns:
+//   - The parameter names below path, empty if there are none
+//   - ErrEmptyName if path is empty
+//   - ErrNoAccess if there are insufficient permissions
+func (c *Client) ListParameterNames(ctx context.Context, path string) ([]string, error) {
+	if path == "" {
+		return nil, ErrEmptyName
+	}
+
+	filterKey := "Path"
+	filterOption := "Recursive"
+	metadata, err := c.describeParameters(ctx, []ssmtypes.ParameterStringFilter{
+		{Key: &filterKey, Option: &filterOption, Values: []string{path}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(metadata))
+	for name := range metadata {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// describeParameters returns the metadata of all parameters matching the
+// filters, keyed by parameter name.
+func (c *Client) describeParameters(ctx context.Context, filters []ssmtypes.ParameterStringFilter) (map[string]ssmtypes.ParameterMetadata, error) {
+	metadata := make(map[string]ssmtypes.ParameterMetadata)
+	input := &ssm.DescribeParametersInput{ParameterFilters: filters}
+	for {
+		output, err := c.SSMClient.DescribeParameters(ctx, input)
+		if err != nil {
+			var ae smithy.APIError
+			if errors.As(err, &ae) {
+				if ae.ErrorCode() == "AccessDeniedException" {
+					return nil, fmt.Errorf("%w to describe parameters", ErrNoAccess)
+				}
+			}
+			return nil, fmt.Errorf("failed to describe parameters: %w", err)
+		}
+		for _, m := range output.Parameters {
+			metadata[aws.ToString(m.Name)] = m
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+	return metadata, nil
+}
+
+// applyMetadata copies the metadata returned by DescribeParameters to param
+func applyMetadata(param *Parameter, m ssmtypes.ParameterMetadata) {
+	param.Description = aws.ToString(m.Description)
+	param.KeyID = aws.ToString(m.KeyId)
+	param.Tier = string(m.Tier)
 }
 
 // CreateParameter creates a new parameter in SSM Parameter Store.
@@ -151,9 +490,10 @@ func (c *Client) GetParameter(ctx context.Context, name string) (string, error)
 //   - name: The full path of the parameter to create
 //   - value: The parameter value
 //   - description: Optional description of the parameter
-//   - paramType: Parameter type (String or SecureString)
+//   - paramType: Parameter type (String, StringList or SecureString)
 //   - kmsKeyID: Optional KMS key ID for SecureString parameters
 //   - overwrite: Whether to overwrite an existing parameter
+//   - opts: Optional settings like the parameter tier
 //
 // Returns:
 //   - ErrEmptyName if name is empty
@@ -161,15 +501,16 @@ func (c *Client) GetParameter(ctx context.Context, name string) (string, error)
 //   - ErrInvalidType if paramType is invalid
 //   - ErrParameterExists if parameter exists and overwrite is false
 //   - ErrNoAccess if there are insufficient permissions
-func (c *Client) CreateParameter(ctx context.Context, name, value, description string, paramType string, kmsKeyID *string, overwrite bool) error {
+func (c *Client) CreateParameter(ctx context.Context, name, value, description string, paramType string, kmsKeyID *string, overwrite bool, opts ...PutOption) error {
 	if name == "" {
 		return ErrEmptyName
 	}
 	if value == "" {
 		return ErrEmptyValue
 	}
-	if paramType != ParameterTypeString && paramType != ParameterTypeSecureString {
-		return fmt.Errorf("%w: %s (must be %s or %s)", ErrInvalidType, paramType, ParameterTypeString, ParameterTypeSecureString)
+	if !IsValidParameterType(paramType) {
+		return fmt.Errorf("%w: %s (must be %s, %s or %s)", ErrInvalidType, paramType,
+			ParameterTypeString, ParameterTypeStringList, ParameterTypeSecureString)
 	}
 
 	input := &ssm.PutParameterInput{
@@ -184,6 +525,10 @@ func (c *Client) CreateParameter(ctx context.Context, name, value, description s
 		input.KeyId = kmsKeyID
 	}
 
+	for _, opt := range opts {
+		opt(input)
+	}
+
 	output, err := c.SSMClient.PutParameter(ctx, input)
 	if err != nil {
 		var pae *ssmtypes.ParameterAlreadyExists
@@ -212,19 +557,27 @@ func (c *Client) CreateParameter(ctx context.Context, name, value, description s
 //   - name: The full path of the parameter to modify
 //   - value: The new parameter value
 //   - description: Optional new description (empty string to keep existing)
+//   - paramType: Optional new parameter type (empty string to keep existing)
+//   - kmsKeyID: Optional new KMS key ID, only used for SecureString parameters
+//   - opts: Optional settings like the parameter tier
 //
 // Returns:
 //   - ErrEmptyName if name is empty
 //   - ErrEmptyValue if value is empty
+//   - ErrInvalidType if paramType is invalid
 //   - ErrNotFound if the parameter doesn't exist
 //   - ErrNoAccess if there are insufficient permissions
-func (c *Client) ModifyParameter(ctx context.Context, name, value, description string) error {
+func (c *Client) ModifyParameter(ctx context.Context, name, value, description, paramType string, kmsKeyID *string, opts ...PutOption) error {
 	if name == "" {
 		return ErrEmptyName
 	}
 	if value == "" {
 		return ErrEmptyValue
 	}
+	if paramType != "" && !IsValidParameterType(paramType) {
+		return fmt.Errorf("%w: %s (must be %s, %s or %s)", ErrInvalidType, paramType,
+			ParameterTypeString, ParameterTypeStringList, ParameterTypeSecureString)
+	}
 
 	allowOverwrite := true
 	input := &ssm.PutParameterInput{
@@ -237,6 +590,18 @@ func (c *Client) ModifyParameter(ctx context.Context, name, value, description s
 		input.Description = &description
 	}
 
+	if paramType != "" {
+		input.Type = ssmtypes.ParameterType(paramType)
+	}
+
+	if kmsKeyID != nil && paramType == ParameterTypeSecureString {
+		input.KeyId = kmsKeyID
+	}
+
+	for _, opt := range opts {
+		opt(input)
+	}
+
 	output, err := c.SSMClient.PutParameter(ctx, input)
 	if err != nil {
 		var pnf *ssmtypes.ParameterNotFound
@@ -294,3 +659,147 @@ func (c *Client) DeleteParameter(ctx context.Context, name string) error {
 
 	return nil
 }
+
+// DeleteParameters deletes multiple parameters from SSM Parameter Store.
+// The names are deleted in batches of 10, a failed batch doesn't stop the
+// remaining batches.
+//
+// Parameters:
+//   - ctx: Context for the AWS API call
+//   - names: The full paths of the parameters to delete
+//
+// Returns:
+//   - An error per name that couldn't be deleted, empty if all were deleted,
+//     ErrNotFound for names that don't exist and ErrNoAccess if there are
+//     insufficient permissions
+//   - ErrEmptyName if a name is empty
+func (c *Client) DeleteParameters(ctx context.Context, names []string) (map[string]error, error) {
+	for _, name := range names {
+		if name == "" {
+			return nil, ErrEmptyName
+		}
+	}
+
+	failed := make(map[string]error)
+	for start := 0; start < len(names); start += maxDeleteBatchSize {
+		batch := names[start:min(start+maxDeleteBatchSize, len(names))]
+		input := &ssm.DeleteParametersInput{Names: batch}
+
+		output, err := c.SSMClient.DeleteParameters(ctx, input)
+		if err != nil {
+			var ae smithy.APIError
+			if errors.As(err, &ae) && ae.ErrorCode() == "AccessDeniedException" {
+				err = fmt.Errorf("%w to delete parameters", ErrNoAccess)
+			} else {
+				err = fmt.Errorf("failed to delete parameters: %w", err)
+			}
+			for _, name := range batch {
+				failed[name] = err
+			}
+			continue
+		}
+
+		for _, name := range output.InvalidParameters {
+			failed[name] = fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+	}
+
+	return failed, nil
+}
+
+// ListTags retrieves the tags of a parameter.
+//
+// Parameters:
+//   - ctx: Context for the AWS API call
+//   - name: The full path of the parameter
+//
+// Returns:
+//   - The tags as key value pairs, empty if the parameter has no tags
+//   - ErrEmptyName if name is empty
+//   - ErrNotFound if the parameter doesn't exist
+//   - ErrNoAccess if there are insufficient permissions
+func (c *Client) ListTags(ctx context.Context, name string) (map[string]string, error) {
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	input := &ssm.ListTagsForResourceInput{
+		ResourceType: ssmtypes.ResourceTypeForTaggingParameter,
+		ResourceId:   &name,
+	}
+
+	output, err := c.SSMClient.ListTagsForResource(ctx, input)
+	if err != nil {
+		var irid *ssmtypes.InvalidResourceId
+		if errors.As(err, &irid) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		var ae smithy.APIError
+		if errors.As(err, &ae) {
+			if ae.ErrorCode() == "AccessDeniedException" {
+				return nil, fmt.Errorf("%w to list tags of parameter %s", ErrNoAccess, name)
+			}
+		}
+		return nil, fmt.Errorf("failed to list tags of parameter %s: %w", name, err)
+	}
+
+	tags := make(map[string]string, len(output.TagList))
+	for _, tag := range output.TagList {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags, nil
+}
+
+// AddTags adds tags to a parameter. Existing tags with the same key are
+// overwritten, other tags are kept.
+//
+// Parameters:
+//   - ctx: Context for the AWS API call
+//   - name: The full path of the parameter
+//   - tags: The tags as key value pairs, nothing is done if empty
+//
+// Returns:
+//   - ErrEmptyName if name is empty
+//   - ErrNotFound if the parameter doesn't exist
+//   - ErrNoAccess if there are insufficient permissions
+func (c *Client) AddTags(ctx context.Context, name string, tags map[string]string) error {
+	if name == "" {
+		return ErrEmptyName
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tagList := make([]ssmtypes.Tag, 0, len(keys))
+	for _, key := range keys {
+		tagList = append(tagList, ssmtypes.Tag{Key: aws.String(key), Value: aws.String(tags[key])})
+	}
+
+	input := &ssm.AddTagsToResourceInput{
+		ResourceType: ssmtypes.ResourceTypeForTaggingParameter,
+		ResourceId:   &name,
+		Tags:         tagList,
+	}
+
+	if _, err := c.SSMClient.AddTagsToResource(ctx, input); err != nil {
+		var irid *ssmtypes.InvalidResourceId
+		if errors.As(err, &irid) {
+			return fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		var ae smithy.APIError
+		if errors.As(err, &ae) {
+			if ae.ErrorCode() == "AccessDeniedException" {
+				return fmt.Errorf("%w to tag parameter %s", ErrNoAccess, name)
+			}
+		}
+		return fmt.Errorf("failed to tag parameter %s: %w", name, err)
+	}
+
+	return nil
+}
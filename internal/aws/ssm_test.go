@@ -6,7 +6,9 @@ package aws
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
@@ -113,6 +115,154 @@ func TestGetParameter(t *testing.T) {
 	}
 }
 
+func TestGetParameterInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		mockFunc func(context.Context, *ssm.GetParameterInput, ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+		want     *Parameter
+		wantErr  bool
+	}{
+		{
+			name: "string list parameter",
+			mockFunc: func(ctx context.Context, input *ssm.GetParameterInput, opts ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+				value := "a,b"
+				return &ssm.GetParameterOutput{
+					Parameter: &types.Parameter{
+						Value:   &value,
+						Type:    types.ParameterTypeStringList,
+						Version: 3,
+					},
+				}, nil
+			},
+			want: &Parameter{Name: "/test/param", Value: "a,b", Type: ParameterTypeStringList, Version: 3},
+		},
+		{
+			name: "parameter not found",
+			mockFunc: func(ctx context.Context, input *ssm.GetParameterInput, opts ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+				return nil, &types.ParameterNotFound{}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{SSMClient: &MockSSMClient{GetParamFunc: tt.mockFunc}}
+			got, err := client.GetParameterInfo(context.Background(), "/test/param")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetParameterInfo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetParameterInfo() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeParameter(t *testing.T) {
+	value := "secret"
+	mock := &MockSSMClient{
+		GetParamFunc: func(ctx context.Context, input *ssm.GetParameterInput, opts ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+			return &ssm.GetParameterOutput{
+				Parameter: &types.Parameter{Value: &value, Type: types.ParameterTypeSecureString, Version: 2},
+			}, nil
+		},
+		DescribeFunc: func(ctx context.Context, input *ssm.DescribeParametersInput, opts ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error) {
+			if len(input.ParameterFilters) != 1 || input.ParameterFilters[0].Values[0] != "/test/secret" {
+				return nil, fmt.Errorf("unexpected filters")
+			}
+			return &ssm.DescribeParametersOutput{
+				Parameters: []types.ParameterMetadata{
+					{Name: strPtr("/test/secret"), Description: strPtr("test secret"), KeyId: strPtr("alias/test-key"), Tier: types.ParameterTierAdvanced},
+				},
+			}, nil
+		},
+	}
+
+	client := &Client{SSMClient: mock}
+	got, err := client.DescribeParameter(context.Background(), "/test/secret")
+	if err != nil {
+		t.Fatalf("DescribeParameter() error = %v", err)
+	}
+	want := &Parameter{
+		Name:        "/test/secret",
+		Value:       "secret",
+		Type:        ParameterTypeSecureString,
+		Version:     2,
+		Description: "test secret",
+		KeyID:       "alias/test-key",
+		Tier:        ParameterTierAdvanced,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DescribeParameter() = %+v, want %+v", got, want)
+	}
+
+	mock.DescribeFunc = func(ctx context.Context, input *ssm.DescribeParametersInput, opts ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error) {
+		return nil, fmt.Errorf("AWS error")
+	}
+	if _, err := client.DescribeParameter(context.Background(), "/test/secret"); err == nil {
+		t.Error("DescribeParameter() expected error when describe fails")
+	}
+}
+
+func TestListParameters(t *testing.T) {
+	pages := map[string]*ssm.GetParametersByPathOutput{
+		"": {
+			Parameters: []types.Parameter{
+				{Name: strPtr("/app/b"), Value: strPtr("b"), Type: types.ParameterTypeString, Version: 1},
+			},
+			NextToken: strPtr("page2"),
+		},
+		"page2": {
+			Parameters: []types.Parameter{
+				{Name: strPtr("/app/a"), Value: strPtr("a"), Type: types.ParameterTypeSecureString, Version: 4},
+			},
+		},
+	}
+	mock := &MockSSMClient{
+		GetByPathFunc: func(ctx context.Context, input *ssm.GetParametersByPathInput, opts ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+			token := ""
+			if input.NextToken != nil {
+				token = *input.NextToken
+			}
+			return pages[token], nil
+		},
+		DescribeFunc: func(ctx context.Context, input *ssm.DescribeParametersInput, opts ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error) {
+			return &ssm.DescribeParametersOutput{
+				Parameters: []types.ParameterMetadata{
+					{Name: strPtr("/app/a"), Description: strPtr("desc a"), KeyId: strPtr("alias/app")},
+					{Name: strPtr("/app/b"), Description: strPtr("desc b")},
+				},
+			}, nil
+		},
+	}
+
+	client := &Client{SSMClient: mock}
+	got, err := client.ListParameters(context.Background(), "/app")
+	if err != nil {
+		t.Fatalf("ListParameters() error = %v", err)
+	}
+	want := []Parameter{
+		{Name: "/app/a", Value: "a", Type: ParameterTypeSecureString, Version: 4, Description: "desc a", KeyID: "alias/app"},
+		{Name: "/app/b", Value: "b", Type: ParameterTypeString, Version: 1, Description: "desc b"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListParameters() = %+v, want %+v", got, want)
+	}
+
+	if _, err := client.ListParameters(context.Background(), ""); err == nil {
+		t.Error("ListParameters() expected error for empty path")
+	}
+
+	mock.GetByPathFunc = func(ctx context.Context, input *ssm.GetParametersByPathInput, opts ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+		return nil, fmt.Errorf("AWS error")
+	}
+	if _, err := client.ListParameters(context.Background(), "/app"); err == nil {
+		t.Error("ListParameters() expected error when listing fails")
+	}
+}
+
 func TestCreateParameter(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -157,6 +307,20 @@ func TestCreateParameter(t *testing.T) {
 			wantErr:     true,
 			errContains: "parameter name is required",
 		},
+		{
+			name:        "successful create string list",
+			paramName:   "/test/list",
+			value:       "a,b,c",
+			description: "test list",
+			paramType:   ParameterTypeStringList,
+			mockFunc: func(ctx context.Context, input *ssm.PutParameterInput, opts ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+				if input.Type != types.ParameterTypeStringList {
+					return nil, fmt.Errorf("unexpected type %s", input.Type)
+				}
+				return &ssm.PutParameterOutput{}, nil
+			},
+			wantErr: false,
+		},
 		{
 			name:        "empty parameter value",
 			paramName:   "/test/param",
@@ -237,10 +401,46 @@ func TestModifyParameter(t *testing.T) {
 		paramName   string
 		value       string
 		description string
+		paramType   string
+		kmsKeyID    *string
 		mockFunc    func(context.Context, *ssm.PutParameterInput, ...func(*ssm.Options)) (*ssm.PutParameterOutput, error)
 		wantErr     bool
 		errContains string
 	}{
+		{
+			name:      "change type and kms key",
+			paramName: "/test/param",
+			value:     "new-value",
+			paramType: ParameterTypeSecureString,
+			kmsKeyID:  strPtr("alias/new-key"),
+			mockFunc: func(ctx context.Context, input *ssm.PutParameterInput, opts ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+				if input.Type != types.ParameterTypeSecureString || input.KeyId == nil || *input.KeyId != "alias/new-key" {
+					return nil, fmt.Errorf("unexpected type %q or key %v", input.Type, input.KeyId)
+				}
+				return &ssm.PutParameterOutput{}, nil
+			},
+			wantErr: false,
+		},
+		{
+			name:      "keep type and kms key",
+			paramName: "/test/param",
+			value:     "new-value",
+			mockFunc: func(ctx context.Context, input *ssm.PutParameterInput, opts ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+				if input.Type != "" || input.KeyId != nil {
+					return nil, fmt.Errorf("unexpected type %q or key %v", input.Type, input.KeyId)
+				}
+				return &ssm.PutParameterOutput{}, nil
+			},
+			wantErr: false,
+		},
+		{
+			name:        "invalid type",
+			paramName:   "/test/param",
+			value:       "new-value",
+			paramType:   "Invalid",
+			wantErr:     true,
+			errContains: "invalid parameter type",
+		},
 		{
 			name:        "successful modify",
 			paramName:   "/test/param",
@@ -305,7 +505,7 @@ func TestModifyParameter(t *testing.T) {
 				},
 			}
 
-			err := client.ModifyParameter(context.Background(), tt.paramName, tt.value, tt.description)
+			err := client.ModifyParameter(context.Background(), tt.paramName, tt.value, tt.description, tt.paramType, tt.kmsKeyID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ModifyParameter() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -319,6 +519,40 @@ func TestModifyParameter(t *testing.T) {
 	}
 }
 
+func TestWithTier(t *testing.T) {
+	var got []types.ParameterTier
+	client := &Client{SSMClient: &MockSSMClient{
+		PutParamFunc: func(ctx context.Context, input *ssm.PutParameterInput, opts ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+			got = append(got, input.Tier)
+			return &ssm.PutParameterOutput{}, nil
+		},
+	}}
+
+	ctx := context.Background()
+	if err := client.CreateParameter(ctx, "/test/param", "value", "", ParameterTypeString, nil, false, WithTier(ParameterTierAdvanced)); err != nil {
+		t.Fatalf("CreateParameter() error = %v", err)
+	}
+	if err := client.ModifyParameter(ctx, "/test/param", "value", "", "", nil, WithTier("")); err != nil {
+		t.Fatalf("ModifyParameter() error = %v", err)
+	}
+
+	want := []types.ParameterTier{types.ParameterTierAdvanced, ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tiers = %v, want %v", got, want)
+	}
+}
+
+func TestIsValidParameterTier(t *testing.T) {
+	for _, tier := range []string{ParameterTierStandard, ParameterTierAdvanced, ParameterTierIntelligentTiering} {
+		if !IsValidParameterTier(tier) {
+			t.Errorf("IsValidParameterTier(%q) = false, want true", tier)
+		}
+	}
+	if IsValidParameterTier("Premium") {
+		t.Error("IsValidParameterTier(\"Premium\") = true, want false")
+	}
+}
+
 func TestNewClient(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -436,3 +670,180 @@ func stringContains(s, substr string) bool {
 func strPtr(s string) *string {
 	return &s
 }
+
+func TestListTags(t *testing.T) {
+	mock := &MockSSMClient{
+		ListTagsFunc: func(ctx context.Context, input *ssm.ListTagsForResourceInput, opts ...func(*ssm.Options)) (*ssm.ListTagsForResourceOutput, error) {
+			if input.ResourceType != types.ResourceTypeForTaggingParameter || *input.ResourceId != "/app/a" {
+				return nil, &types.InvalidResourceId{}
+			}
+			return &ssm.ListTagsForResourceOutput{TagList: []types.Tag{
+				{Key: strPtr("team"), Value: strPtr("platform")},
+			}}, nil
+		},
+	}
+
+	client := &Client{SSMClient: mock}
+	got, err := client.ListTags(context.Background(), "/app/a")
+	if err != nil {
+		t.Fatalf("ListTags() error = %v", err)
+	}
+	if want := map[string]string{"team": "platform"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListTags() = %v, want %v", got, want)
+	}
+
+	if _, err := client.ListTags(context.Background(), "/app/missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ListTags() error = %v, want ErrNotFound", err)
+	}
+	if _, err := client.ListTags(context.Background(), ""); err == nil {
+		t.Error("ListTags() expected error for empty name")
+	}
+}
+
+func TestAddTags(t *testing.T) {
+	var got []types.Tag
+	mock := &MockSSMClient{
+		AddTagsFunc: func(ctx context.Context, input *ssm.AddTagsToResourceInput, opts ...func(*ssm.Options)) (*ssm.AddTagsToResourceOutput, error) {
+			got = input.Tags
+			return &ssm.AddTagsToResourceOutput{}, nil
+		},
+	}
+
+	client := &Client{SSMClient: mock}
+	if err := client.AddTags(context.Background(), "/app/a", map[string]string{"b": "2", "a": "1"}); err != nil {
+		t.Fatalf("AddTags() error = %v", err)
+	}
+	if len(got) != 2 || *got[0].Key != "a" || *got[1].Value != "2" {
+		t.Errorf("AddTags() sent tags %+v, want a=1 and b=2 sorted by key", got)
+	}
+
+	// No API call without tags
+	mock.AddTagsFunc = func(ctx context.Context, input *ssm.AddTagsToResourceInput, opts ...func(*ssm.Options)) (*ssm.AddTagsToResourceOutput, error) {
+		return nil, fmt.Errorf("AWS error")
+	}
+	if err := client.AddTags(context.Background(), "/app/a", nil); err != nil {
+		t.Errorf("AddTags() error = %v for empty tags", err)
+	}
+	if err := client.AddTags(context.Background(), "/app/a", map[string]string{"a": "1"}); err == nil {
+		t.Error("AddTags() expected error when tagging fails")
+	}
+}
+
+func TestListParameterNames(t *testing.T) {
+	mock := &MockSSMClient{
+		DescribeFunc: func(ctx context.Context, input *ssm.DescribeParametersInput, opts ...func(*ssm.Options)) (*ssm.DescribeParametersOutput, error) {
+			return &ssm.DescribeParametersOutput{
+				Parameters: []types.ParameterMetadata{{Name: strPtr("/app/b")}, {Name: strPtr("/app/a")}},
+			}, nil
+		},
+	}
+
+	client := &Client{SSMClient: mock}
+	got, err := client.ListParameterNames(context.Background(), "/app")
+	if err != nil {
+		t.Fatalf("ListParameterNames() error = %v", err)
+	}
+	if want := []string{"/app/a", "/app/b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListParameterNames() = %v, want %v", got, want)
+	}
+
+	if _, err := client.ListParameterNames(context.Background(), ""); err == nil {
+		t.Error("ListParameterNames() expected error for empty path")
+	}
+}
+
+func TestDeleteParameters(t *testing.T) {
+	names := make([]string, 25)
+	for i := range names {
+		names[i] = fmt.Sprintf("/app/p%02d", i)
+	}
+
+	var batches [][]string
+	mock := &MockSSMClient{
+		DeleteParamsFunc: func(ctx context.Context, input *ssm.DeleteParametersInput, opts ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error) {
+			batches = append(batches, input.Names)
+			switch len(batches) {
+			case 1:
+				return &ssm.DeleteParametersOutput{DeletedParameters: input.Names[1:], InvalidParameters: input.Names[:1]}, nil
+			case 2:
+				return nil, fmt.Errorf("AWS error")
+			}
+			return &ssm.DeleteParametersOutput{DeletedParameters: input.Names}, nil
+		},
+	}
+
+	client := &Client{SSMClient: mock}
+	failed, err := client.DeleteParameters(context.Background(), names)
+	if err != nil {
+		t.Fatalf("DeleteParameters() error = %v", err)
+	}
+
+	var sizes []int
+	for _, batch := range batches {
+		sizes = append(sizes, len(batch))
+	}
+	if want := []int{10, 10, 5}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("DeleteParameters() batch sizes = %v, want %v", sizes, want)
+	}
+	if len(failed) != 11 {
+		t.Errorf("DeleteParameters() failed for %d name(s), want 11", len(failed))
+	}
+	if !errors.Is(failed["/app/p00"], ErrNotFound) {
+		t.Errorf("DeleteParameters() error for invalid name = %v, want ErrNotFound", failed["/app/p00"])
+	}
+	if _, ok := failed["/app/p20"]; ok {
+		t.Error("DeleteParameters() reported failure for a deleted name")
+	}
+
+	if _, err := client.DeleteParameters(context.Background(), []string{""}); err == nil {
+		t.Error("DeleteParameters() expected error for empty name")
+	}
+}
+
+func TestGetParameters(t *testing.T) {
+	names := make([]string, 15)
+	for i := range names {
+		names[i] = fmt.Sprintf("/app/p%02d", i)
+	}
+
+	var sizes []int
+	mock := &MockSSMClient{
+		GetParamsFunc: func(ctx context.Context, input *ssm.GetParametersInput, opts ...func(*ssm.Options)) (*ssm.GetParametersOutput, error) {
+			sizes = append(sizes, len(input.Names))
+			output := &ssm.GetParametersOutput{}
+			for _, name := range input.Names {
+				if name == "/app/missing" {
+					output.InvalidParameters = append(output.InvalidParameters, name)
+					continue
+				}
+				output.Parameters = append(output.Parameters, types.Parameter{
+					Name: strPtr(name), Value: strPtr("value of " + name), Type: types.ParameterTypeSecureString, Version: 1,
+				})
+			}
+			return output, nil
+		},
+	}
+
+	client := &Client{SSMClient: mock}
+	got, err := client.GetParameters(context.Background(), names)
+	if err != nil {
+		t.Fatalf("GetParameters() error = %v", err)
+	}
+	if want := []int{10, 5}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("GetParameters() batch sizes = %v, want %v", sizes, want)
+	}
+	if len(got) != len(names) {
+		t.Errorf("GetParameters() returned %d parameter(s), want %d", len(got), len(names))
+	}
+	want := Parameter{Name: "/app/p14", Value: "value of /app/p14", Type: ParameterTypeSecureString, Version: 1}
+	if got["/app/p14"] != want {
+		t.Errorf("GetParameters() = %+v, want %+v", got["/app/p14"], want)
+	}
+
+	if _, err := client.GetParameters(context.Background(), []string{"/app/p00", "/app/missing"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetParameters() error = %v, want ErrNotFound", err)
+	}
+	if _, err := client.GetParameters(context.Background(), []string{""}); err == nil {
+		t.Error("GetParameters() expected error for empty name")
+	}
+}
@@ -0,0 +1,253 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+// Package backup reads and writes portable parameter backups.
+//
+// A backup holds the parameters below a prefix with their value, type,
+// description, KMS key ID, tier, tags and version. It is written as JSON or
+// YAML and can be encrypted with a passphrase. Encrypted backups use
+// AES-256-GCM with a key derived by PBKDF2-SHA256 and are stored as a JSON
+// envelope, so they are still recognised as backups.
+package backup
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Supported backup formats
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// formatVersion is the version of the backup format
+const formatVersion = 1
+
+// Key derivation settings of encrypted backups
+const (
+	kdfName       = "pbkdf2-sha256"
+	kdfIterations = 600000
+	// maxIterations limits the key derivation of a backup, so a modified
+	// file can't make decryption hang
+	maxIterations = 4 * kdfIterations
+	keyLength     = 32
+	saltLength    = 16
+)
+
+// Common errors returned by the package
+var (
+	ErrInvalidBackup   = errors.New("invalid backup")
+	ErrWrongPassphrase = errors.New("wrong passphrase or corrupted backup")
+)
+
+// Backup is a snapshot of the parameters below a prefix
+type Backup struct {
+	// FormatVersion is the version of the backup format
+	FormatVersion int `json:"format_version" yaml:"format_version"`
+	// Prefix is the exported parameter path
+	Prefix string `json:"prefix" yaml:"prefix"`
+	// Region is the AWS region the parameters were exported from
+	Region string `json:"region" yaml:"region"`
+	// Created is the time of the export
+	Created time.Time `json:"created" yaml:"created"`
+	// Parameters are the exported parameters sorted by name
+	Parameters []Parameter `json:"parameters" yaml:"parameters"`
+}
+
+// Parameter is a single exported parameter
+type Parameter struct {
+	Name        string            `json:"name" yaml:"name"`
+	Type        string            `json:"type" yaml:"type"`
+	Value       string            `json:"value" yaml:"value"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	KeyID       string            `json:"kms_key_id,omitempty" yaml:"kms_key_id,omitempty"`
+	Tier        string            `json:"tier,omitempty" yaml:"tier,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Version     int64             `json:"version" yaml:"version"`
+}
+
+// envelope is the JSON representation of an encrypted backup
+type envelope struct {
+	Encrypted  string `json:"params2env_encrypted"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// New creates a backup of the given parameters
+func New(prefix, region string, params []Parameter) *Backup {
+	return &Backup{
+		FormatVersion: formatVersion,
+		Prefix:        prefix,
+		Region:        region,
+		Created:       time.Now().UTC().Truncate(time.Second),
+		Parameters:    params,
+	}
+}
+
+// Marshal encodes a backup as JSON or YAML
+//
+// Parameters:
+//   - b: The backup to encode
+//   - format: FormatJSON or FormatYAML
+//
+// Returns:
+//   - The encoded backup
+//   - An error if the format is unknown
+func Marshal(b *Backup, format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(b, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case FormatYAML:
+		return yaml.Marshal(b)
+	}
+	return nil, fmt.Errorf("invalid format: %s (must be '%s' or '%s')", format, FormatJSON, FormatYAML)
+}
+
+// Unmarshal decodes a JSON or YAML backup. Encrypted backups have to be
+// decrypted first.
+//
+// Parameters:
+//   - data: The encoded backup
+//
+// Returns:
+//   - The decoded backup
+//   - ErrInvalidBackup if the data isn't a supported backup
+func Unmarshal(data []byte) (*Backup, error) {
+	if IsEncrypted(data) {
+		return nil, fmt.Errorf("%w: backup is encrypted", ErrInvalidBackup)
+	}
+
+	var b Backup
+	// YAML is a superset of JSON, so both formats are decoded the same way
+	if err := yaml.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+	if b.FormatVersion != formatVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %d", ErrInvalidBackup, b.FormatVersion)
+	}
+	if !strings.HasPrefix(b.Prefix, "/") {
+		return nil, fmt.Errorf("%w: missing prefix", ErrInvalidBackup)
+	}
+	for i, p := range b.Parameters {
+		if p.Name == "" || p.Type == "" || p.Value == "" {
+			return nil, fmt.Errorf("%w: parameter at index %d is incomplete", ErrInvalidBackup, i)
+		}
+		if !strings.HasPrefix(p.Name, strings.TrimSuffix(b.Prefix, "/")+"/") {
+			return nil, fmt.Errorf("%w: parameter %s is not below prefix %s", ErrInvalidBackup, p.Name, b.Prefix)
+		}
+	}
+	return &b, nil
+}
+
+// IsEncrypted reports whether data is an encrypted backup
+func IsEncrypted(data []byte) bool {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return false
+	}
+	var env envelope
+	return json.Unmarshal(data, &env) == nil && env.Encrypted != ""
+}
+
+// Encrypt encrypts an encoded backup with a passphrase
+//
+// Parameters:
+//   - data: The encoded backup
+//   - passphrase: The passphrase to derive the key from
+//
+// Returns:
+//   - The encrypted backup as JSON envelope
+//   - An error if the passphrase is empty or encryption fails
+func Encrypt(data []byte, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("passphrase is required")
+	}
+
+	env := envelope{Encrypted: kdfName, Iterations: kdfIterations, Salt: make([]byte, saltLength)}
+	if _, err := rand.Read(env.Salt); err != nil {
+		return nil, fmt.Errorf("failed to read random data: %w", err)
+	}
+
+	gcm, err := newGCM(passphrase, env.Salt, env.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	env.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return nil, fmt.Errorf("failed to read random data: %w", err)
+	}
+	env.Ciphertext = gcm.Seal(nil, env.Nonce, data, nil)
+
+	out, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// Decrypt decrypts an encrypted backup
+//
+// Parameters:
+//   - data: The encrypted backup as JSON envelope
+//   - passphrase: The passphrase used for encryption
+//
+// Returns:
+//   - The encoded backup
+//   - ErrInvalidBackup if data isn't an encrypted backup
+//   - ErrWrongPassphrase if the passphrase is wrong or the data was modified
+func Decrypt(data []byte, passphrase string) ([]byte, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil || env.Encrypted == "" {
+		return nil, fmt.Errorf("%w: backup isn't encrypted", ErrInvalidBackup)
+	}
+	if env.Encrypted != kdfName || env.Iterations < 1 {
+		return nil, fmt.Errorf("%w: unsupported encryption %s", ErrInvalidBackup, env.Encrypted)
+	}
+	if env.Iterations > maxIterations {
+		return nil, fmt.Errorf("%w: %d iterations exceed the maximum of %d", ErrInvalidBackup, env.Iterations, maxIterations)
+	}
+
+	gcm, err := newGCM(passphrase, env.Salt, env.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("%w: invalid nonce", ErrInvalidBackup)
+	}
+	plain, err := gcm.Open(nil, env.Nonce, env.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plain, nil
+}
+
+// newGCM derives the key from the passphrase and returns an AES-GCM cipher
+func newGCM(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
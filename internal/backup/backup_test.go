@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testBackup() *Backup {
+	return New("/app", "eu-central-1", []Parameter{
+		{Name: "/app/url", Type: "String", Value: "https://example.com", Description: "Service URL", Tier: "Standard", Version: 3},
+		{
+			Name: "/app/password", Type: "SecureString", Value: "line1\nline2", KeyID: "alias/app",
+			Tags: map[string]string{"team": "platform"}, Version: 1,
+		},
+	})
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	for _, format := range []string{FormatJSON, FormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			want := testBackup()
+			data, err := Marshal(want, format)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if format == FormatJSON && !bytes.HasPrefix(data, []byte("{")) {
+				t.Errorf("Marshal() = %s, want JSON", data)
+			}
+			if IsEncrypted(data) {
+				t.Error("IsEncrypted() = true for plain backup")
+			}
+
+			got, err := Unmarshal(data)
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !got.Created.Equal(want.Created) {
+				t.Errorf("Created = %v, want %v", got.Created, want.Created)
+			}
+			got.Created = want.Created
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	if _, err := Marshal(testBackup(), "xml"); err == nil || !strings.Contains(err.Error(), "invalid format") {
+		t.Errorf("Marshal() error = %v, want invalid format", err)
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "not yaml", data: "parameters: [\n"},
+		{name: "missing version", data: "prefix: /app\nparameters: []\n"},
+		{name: "future version", data: "format_version: 2\n"},
+		{name: "missing prefix", data: "format_version: 1\nparameters: []\n"},
+		{name: "incomplete parameter", data: "format_version: 1\nprefix: /app\nparameters:\n  - name: /app/a\n    type: String\n"},
+		{name: "outside prefix", data: "format_version: 1\nprefix: /app\nparameters:\n  - name: /other/a\n    type: String\n    value: x\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Unmarshal([]byte(tt.data)); !errors.Is(err, ErrInvalidBackup) {
+				t.Errorf("Unmarshal() error = %v, want ErrInvalidBackup", err)
+			}
+		})
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	plain, err := Marshal(testBackup(), FormatYAML)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	encrypted, err := Encrypt(plain, "correct horse")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if !IsEncrypted(encrypted) {
+		t.Error("IsEncrypted() = false for encrypted backup")
+	}
+	if bytes.Contains(encrypted, []byte("example.com")) {
+		t.Error("encrypted backup contains plain text values")
+	}
+	if _, err := Unmarshal(encrypted); !errors.Is(err, ErrInvalidBackup) {
+		t.Errorf("Unmarshal() of encrypted backup error = %v, want ErrInvalidBackup", err)
+	}
+
+	got, err := Decrypt(encrypted, "correct horse")
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if !bytes.Equal(got, plain) {
+		t.Errorf("Decrypt() = %s, want %s", got, plain)
+	}
+
+	if _, err := Decrypt(encrypted, "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Decrypt() with wrong passphrase error = %v, want ErrWrongPassphrase", err)
+	}
+	if _, err := Decrypt(plain, "correct horse"); !errors.Is(err, ErrInvalidBackup) {
+		t.Errorf("Decrypt() of plain backup error = %v, want ErrInvalidBackup", err)
+	}
+	tampered := bytes.Replace(encrypted, []byte(`"iterations": 600000`), []byte(`"iterations": 2147483647`), 1)
+	if bytes.Equal(tampered, encrypted) {
+		t.Fatal("encrypted backup doesn't contain the iterations")
+	}
+	if _, err := Decrypt(tampered, "correct horse"); !errors.Is(err, ErrInvalidBackup) {
+		t.Errorf("Decrypt() with too many iterations error = %v, want ErrInvalidBackup", err)
+	}
+	if _, err := Encrypt(plain, ""); err == nil {
+		t.Error("Encrypt() expected error for empty passphrase")
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -29,28 +30,75 @@ var (
 	ErrInvalidConfig = errors.New("invalid configuration")
 )
 
+// Output formats of the read command
+const (
+	// OutputShell renders shell export statements
+	OutputShell = "shell"
+	// OutputDocker renders a Docker env file
+	OutputDocker = "docker"
+	// OutputSystemd renders a systemd EnvironmentFile
+	OutputSystemd = "systemd"
+	// OutputK8s renders a Kubernetes Secret and ConfigMap manifest
+	OutputK8s = "k8s"
+	// OutputGitHub appends to the GitHub Actions environment file
+	OutputGitHub = "github"
+	// OutputGitLabDotenv renders a GitLab CI dotenv report
+	OutputGitLabDotenv = "gitlab-dotenv"
+)
+
+// OutputFormats lists all supported output formats
+var OutputFormats = []string{OutputShell, OutputDocker, OutputSystemd, OutputK8s, OutputGitHub, OutputGitLabDotenv}
+
+// Naming strategies for environment variables derived from parameter paths
+const (
+	// EnvNamingBase uses the last path segment, /app/db/url becomes URL
+	EnvNamingBase = "base"
+	// EnvNamingPath uses the full path, /app/db/url becomes APP_DB_URL
+	EnvNamingPath = "path"
+	// EnvNamingRelative uses the path below StripPrefix, /app/db/url
+	// becomes DB_URL with strip_prefix /app
+	EnvNamingRelative = "relative"
+)
+
+// EnvNamings lists all supported naming strategies
+var EnvNamings = []string{EnvNamingBase, EnvNamingPath, EnvNamingRelative}
+
 // Config represents the main configuration structure for params2env.
 // It defines global settings that apply to all parameter operations
 // unless overridden by specific parameter configurations.
 type Config struct {
 	// Region is the default AWS region for operations
 	Region string `yaml:"region,omitempty"`
-	// Replica is the region where parameters should be replicated
+	// Replica is the region where parameters should be replicated.
+	// Deprecated: use Replicas, kept for backwards compatibility.
 	Replica string `yaml:"replica,omitempty"`
+	// Replicas are the regions where parameters should be replicated
+	Replicas []string `yaml:"replicas,omitempty"`
 	// Prefix is the common prefix for all parameter paths
 	Prefix string `yaml:"prefix,omitempty"`
 	// Output defines the default output format
 	Output string `yaml:"output,omitempty"`
 	// File is the path where parameter values should be written
 	File string `yaml:"file,omitempty"`
+	// OutputDir is the directory where one file per parameter is written
+	OutputDir string `yaml:"output_dir,omitempty"`
 	// Upper determines if environment variable names should be uppercase
 	Upper *bool `yaml:"upper,omitempty"`
 	// EnvPrefix is prepended to all environment variable names
 	EnvPrefix string `yaml:"env_prefix,omitempty"`
+	// EnvNaming is the strategy that derives environment variable names
+	// from parameter paths, one of EnvNamings
+	EnvNaming string `yaml:"env_naming,omitempty"`
+	// StripPrefix is removed from parameter paths by the relative naming
+	// strategy
+	StripPrefix string `yaml:"strip_prefix,omitempty"`
 	// Role is the AWS IAM role to assume for operations
 	Role string `yaml:"role,omitempty"`
 	// KMS is the default KMS key ID for SecureString parameters
 	KMS string `yaml:"kms,omitempty"`
+	// KMSKeys maps a region to the KMS key ID used for SecureString
+	// parameters in that region, e.g. for replicas
+	KMSKeys map[string]string `yaml:"kms_keys,omitempty"`
 	// Params defines specific parameter configurations
 	Params []ParamConfig `yaml:"params,omitempty"`
 }
@@ -64,8 +112,14 @@ type ParamConfig struct {
 	Env string `yaml:"env,omitempty"`
 	// Region overrides the global AWS region for this parameter
 	Region string `yaml:"region,omitempty"`
-	// Output overrides the global output format for this parameter
+	// Output is the format this parameter is rendered in by the read
+	// command, one of OutputFormats
 	Output string `yaml:"output,omitempty"`
+	// ListFormat defines how StringList values are rendered, either
+	// "join" (single variable) or "split" (one variable per item)
+	ListFormat string `yaml:"list_format,omitempty"`
+	// ListSeparator replaces the comma between StringList items when joined
+	ListSeparator string `yaml:"list_separator,omitempty"`
 }
 
 // Validate checks if the configuration is valid.
@@ -76,6 +130,14 @@ func (c *Config) Validate() error {
 		if param.Name == "" {
 			return fmt.Errorf("%w: parameter at index %d missing name", ErrInvalidConfig, i)
 		}
+		if param.ListFormat != "" && param.ListFormat != "join" && param.ListFormat != "split" {
+			return fmt.Errorf("%w: invalid list format %q for parameter %s (must be 'join' or 'split')",
+				ErrInvalidConfig, param.ListFormat, param.Name)
+		}
+		if param.Output != "" && !slices.Contains(OutputFormats, param.Output) {
+			return fmt.Errorf("%w: invalid output format %q for parameter %s (must be one of %s)",
+				ErrInvalidConfig, param.Output, param.Name, strings.Join(OutputFormats, ", "))
+		}
 	}
 
 	// Validate output format if specified
@@ -83,9 +145,25 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("%w: invalid output format %q (must be 'env' or 'file')", ErrInvalidConfig, c.Output)
 	}
 
+	if c.EnvNaming != "" && !slices.Contains(EnvNamings, c.EnvNaming) {
+		return fmt.Errorf("%w: invalid env naming %q (must be one of %s)",
+			ErrInvalidConfig, c.EnvNaming, strings.Join(EnvNamings, ", "))
+	}
+
 	return nil
 }
 
+// ReplicaRegions returns all configured replica regions. Regions from the
+// replicas list come first, followed by the legacy replica setting if it
+// isn't already part of the list.
+func (c *Config) ReplicaRegions() []string {
+	regions := append([]string{}, c.Replicas...)
+	if c.Replica != "" && !slices.Contains(regions, c.Replica) {
+		regions = append(regions, c.Replica)
+	}
+	return regions
+}
+
 // LoadConfig loads configuration from files with precedence:
 // 1. Current directory (.params2env.yaml)
 // 2. Home directory (~/.params2env.yaml)
@@ -151,7 +229,7 @@ func loadFile(filename string, cfg *Config) error {
 // mergeConfig merges local configuration into global configuration.
 // Local settings take precedence over global settings. For slices
 // (like Params), the local values completely replace global values
-// rather than being merged.
+// rather than being merged. Maps (like KMSKeys) are merged per key.
 func mergeConfig(global, local *Config) {
 	// Merge string fields
 	if local.Region != "" {
@@ -169,9 +247,18 @@ func mergeConfig(global, local *Config) {
 	if local.File != "" {
 		global.File = local.File
 	}
+	if local.OutputDir != "" {
+		global.OutputDir = local.OutputDir
+	}
 	if local.EnvPrefix != "" {
 		global.EnvPrefix = local.EnvPrefix
 	}
+	if local.EnvNaming != "" {
+		global.EnvNaming = local.EnvNaming
+	}
+	if local.StripPrefix != "" {
+		global.StripPrefix = local.StripPrefix
+	}
 	if local.Role != "" {
 		global.Role = local.Role
 	}
@@ -185,9 +272,22 @@ func mergeConfig(global, local *Config) {
 	}
 
 	// Merge slice fields
+	if len(local.Replicas) > 0 {
+		global.Replicas = local.Replicas
+	}
 	if len(local.Params) > 0 {
 		global.Params = local.Params
 	}
+
+	// Merge map fields, local entries override global ones per key
+	if len(local.KMSKeys) > 0 {
+		if global.KMSKeys == nil {
+			global.KMSKeys = make(map[string]string, len(local.KMSKeys))
+		}
+		for region, key := range local.KMSKeys {
+			global.KMSKeys[region] = key
+		}
+	}
 }
 
 // sanitizeForLog removes control characters that could be used for log injection (CWE-117 mitigation)
@@ -253,6 +253,7 @@ func TestMergeConfig(t *testing.T) {
 				File:      "~/.env",
 				Upper:     boolPtr(false),
 				EnvPrefix: "GLOBAL_",
+				EnvNaming: EnvNamingBase,
 				Role:      "arn:aws:iam::123:role/global",
 				KMS:       "alias/global-key",
 				Params: []ParamConfig{
@@ -260,29 +261,33 @@ func TestMergeConfig(t *testing.T) {
 				},
 			},
 			local: &Config{
-				Region:    "eu-central-1",
-				Replica:   "eu-west-1",
-				Prefix:    "/local",
-				Output:    "file",
-				File:      "./local.env",
-				Upper:     boolPtr(true),
-				EnvPrefix: "LOCAL_",
-				Role:      "arn:aws:iam::123:role/local",
-				KMS:       "alias/local-key",
+				Region:      "eu-central-1",
+				Replica:     "eu-west-1",
+				Prefix:      "/local",
+				Output:      "file",
+				File:        "./local.env",
+				Upper:       boolPtr(true),
+				EnvPrefix:   "LOCAL_",
+				EnvNaming:   EnvNamingRelative,
+				StripPrefix: "/local",
+				Role:        "arn:aws:iam::123:role/local",
+				KMS:         "alias/local-key",
 				Params: []ParamConfig{
 					{Name: "/local/param"},
 				},
 			},
 			want: &Config{
-				Region:    "eu-central-1",
-				Replica:   "eu-west-1",
-				Prefix:    "/local",
-				Output:    "file",
-				File:      "./local.env",
-				Upper:     boolPtr(true),
-				EnvPrefix: "LOCAL_",
-				Role:      "arn:aws:iam::123:role/local",
-				KMS:       "alias/local-key",
+				Region:      "eu-central-1",
+				Replica:     "eu-west-1",
+				Prefix:      "/local",
+				Output:      "file",
+				File:        "./local.env",
+				Upper:       boolPtr(true),
+				EnvPrefix:   "LOCAL_",
+				EnvNaming:   EnvNamingRelative,
+				StripPrefix: "/local",
+				Role:        "arn:aws:iam::123:role/local",
+				KMS:         "alias/local-key",
 				Params: []ParamConfig{
 					{Name: "/local/param"},
 				},
@@ -332,6 +337,132 @@ func TestMergeConfig(t *testing.T) {
 	}
 }
 
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr bool
+	}{
+		{
+			name:    "empty config",
+			cfg:     &Config{},
+			wantErr: false,
+		},
+		{
+			name:    "param missing name",
+			cfg:     &Config{Params: []ParamConfig{{Env: "TEST"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid output",
+			cfg:     &Config{Output: "xml"},
+			wantErr: true,
+		},
+		{
+			name: "valid list formats",
+			cfg: &Config{Params: []ParamConfig{
+				{Name: "/test/join", ListFormat: "join", ListSeparator: ";"},
+				{Name: "/test/split", ListFormat: "split"},
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid list format",
+			cfg:     &Config{Params: []ParamConfig{{Name: "/test/list", ListFormat: "array"}}},
+			wantErr: true,
+		},
+		{
+			name:    "valid parameter output format",
+			cfg:     &Config{Params: []ParamConfig{{Name: "/test/a", Output: OutputK8s}}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid parameter output format",
+			cfg:     &Config{Params: []ParamConfig{{Name: "/test/a", Output: "xml"}}},
+			wantErr: true,
+		},
+		{
+			name:    "valid env naming",
+			cfg:     &Config{EnvNaming: EnvNamingRelative, StripPrefix: "/app"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid env naming",
+			cfg:     &Config{EnvNaming: "flat"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReplicaRegions(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		want []string
+	}{
+		{
+			name: "no replicas",
+			cfg:  &Config{},
+			want: []string{},
+		},
+		{
+			name: "legacy replica",
+			cfg:  &Config{Replica: "eu-west-1"},
+			want: []string{"eu-west-1"},
+		},
+		{
+			name: "replicas list",
+			cfg:  &Config{Replicas: []string{"eu-west-1", "us-east-1"}},
+			want: []string{"eu-west-1", "us-east-1"},
+		},
+		{
+			name: "legacy replica merged into list",
+			cfg:  &Config{Replica: "ap-southeast-1", Replicas: []string{"eu-west-1"}},
+			want: []string{"eu-west-1", "ap-southeast-1"},
+		},
+		{
+			name: "legacy replica already in list",
+			cfg:  &Config{Replica: "eu-west-1", Replicas: []string{"eu-west-1", "us-east-1"}},
+			want: []string{"eu-west-1", "us-east-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.ReplicaRegions(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReplicaRegions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeConfigKMSKeys(t *testing.T) {
+	global := &Config{KMSKeys: map[string]string{"eu-west-1": "alias/global", "us-east-1": "alias/global"}}
+	local := &Config{
+		Replicas: []string{"eu-west-1"},
+		KMSKeys:  map[string]string{"eu-west-1": "alias/local"},
+	}
+
+	mergeConfig(global, local)
+
+	want := map[string]string{"eu-west-1": "alias/local", "us-east-1": "alias/global"}
+	if !reflect.DeepEqual(global.KMSKeys, want) {
+		t.Errorf("mergeConfig() KMSKeys = %v, want %v", global.KMSKeys, want)
+	}
+	if !reflect.DeepEqual(global.Replicas, []string{"eu-west-1"}) {
+		t.Errorf("mergeConfig() Replicas = %v, want [eu-west-1]", global.Replicas)
+	}
+}
+
 // Helper function to create bool pointer
 func boolPtr(b bool) *bool {
 	return &b
@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+// Package dotenv parses .env files.
+//
+// The supported syntax follows the common dotenv conventions:
+//   - KEY=value pairs, one per line, optionally prefixed with "export"
+//   - Blank lines and lines starting with # are ignored
+//   - Unquoted values are trimmed and end at " #", which starts a comment
+//   - Single quoted values are taken literally and may span multiple lines
+//   - Double quoted values may span multiple lines and support the escapes
+//     \n, \r, \t, \", \\ and \$
+//
+// A key that is defined more than once takes the last value.
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ErrSyntax is returned for malformed input
+var ErrSyntax = errors.New("invalid dotenv syntax")
+
+// keyPattern matches valid variable names
+var keyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// Entry is a single variable of a .env file
+type Entry struct {
+	// Key is the variable name
+	Key string
+	// Value is the unquoted and unescaped value
+	Value string
+	// Line is the line number the variable was defined on
+	Line int
+}
+
+// Parse reads a .env file and returns its variables in the order they were
+// first defined
+//
+// Parameters:
+//   - r: The .env content
+//
+// Returns:
+//   - The variables of the file
+//   - ErrSyntax with the line number if the content is malformed
+func Parse(r io.Reader) ([]Entry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: strings.ReplaceAll(string(data), "\r\n", "\n"), line: 1}
+
+	var entries []Entry
+	index := make(map[string]int)
+	for {
+		entry, ok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return entries, nil
+		}
+		if i, seen := index[entry.Key]; seen {
+			entries[i].Value, entries[i].Line = entry.Value, entry.Line
+			continue
+		}
+		index[entry.Key] = len(entries)
+		entries = append(entries, entry)
+	}
+}
+
+// parser holds the state of a single Parse call
+type parser struct {
+	src  string
+	pos  int
+	line int
+}
+
+// next returns the next entry, ok is false at the end of the input
+func (p *parser) next() (entry Entry, ok bool, err error) {
+	for p.pos < len(p.src) {
+		lineStart := p.pos
+		line := p.readLine()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			p.line++
+			continue
+		}
+
+		entry.Line = p.line
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 {
+			return entry, false, p.errorf("missing '=' after %s", trimmed)
+		}
+		key := strings.TrimSpace(line[:eq])
+		if rest, found := strings.CutPrefix(key, "export"); found && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+			key = strings.TrimSpace(rest)
+		}
+		if !keyPattern.MatchString(key) {
+			return entry, false, p.errorf("invalid variable name %q", key)
+		}
+		entry.Key = key
+
+		value := strings.TrimLeft(line[eq+1:], " \t")
+		if value == "" || (value[0] != '"' && value[0] != '\'') {
+			entry.Value = unquoted(value)
+			p.line++
+			return entry, true, nil
+		}
+
+		// A quoted value may continue on the following lines, so parsing
+		// restarts at the opening quote
+		p.pos = lineStart + len(line) - len(value)
+		if entry.Value, err = p.quoted(); err != nil {
+			return entry, false, err
+		}
+		p.line++
+		return entry, true, nil
+	}
+	return entry, false, nil
+}
+
+// readLine returns the line at the current position and moves past it
+func (p *parser) readLine() string {
+	end := strings.IndexByte(p.src[p.pos:], '\n')
+	if end < 0 {
+		line := p.src[p.pos:]
+		p.pos = len(p.src)
+		return line
+	}
+	line := p.src[p.pos : p.pos+end]
+	p.pos += end + 1
+	return line
+}
+
+// quoted parses a quoted value starting at the opening quote, followed by
+// an optional comment up to the end of the line
+func (p *parser) quoted() (string, error) {
+	quote := p.src[p.pos]
+	start := p.line
+	p.pos++
+
+	var b strings.Builder
+	for {
+		if p.pos >= len(p.src) {
+			p.line = start
+			return "", p.errorf("unterminated quoted value")
+		}
+		c := p.src[p.pos]
+		p.pos++
+		switch {
+		case c == quote:
+			return b.String(), p.endOfLine()
+		case c == '\n':
+			p.line++
+			b.WriteByte(c)
+		case c == '\\' && quote == '"' && p.pos < len(p.src):
+			escaped := p.src[p.pos]
+			p.pos++
+			switch escaped {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\', '$':
+				b.WriteByte(escaped)
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(escaped)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+}
+
+// endOfLine checks that only whitespace or a comment follows a quoted value
+func (p *parser) endOfLine() error {
+	rest := strings.TrimSpace(p.readLine())
+	if rest != "" && !strings.HasPrefix(rest, "#") {
+		return p.errorf("unexpected %q after quoted value", rest)
+	}
+	return nil
+}
+
+// unquoted returns an unquoted value without surrounding whitespace and a
+// trailing comment
+func unquoted(value string) string {
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = value[:i]
+	}
+	if i := strings.Index(value, "\t#"); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(value)
+}
+
+// errorf returns a syntax error for the current line
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: line %d: %s", ErrSyntax, p.line, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package dotenv
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	input := `# Database settings
+DB_HOST=localhost
+export DB_PORT = 5432   # default port
+DB_PASSWORD="p@ss\"word\n$HOME \$HOME"
+URL=https://example.com/#anchor
+EMPTY=
+SINGLE='no \n escapes # here' # comment
+
+CERT="-----BEGIN-----
+line2
+-----END-----"
+LITERAL='a
+b'
+DB_HOST=db.internal
+export_dir=/tmp
+`
+
+	want := []Entry{
+		{Key: "DB_HOST", Value: "db.internal", Line: 14},
+		{Key: "DB_PORT", Value: "5432", Line: 3},
+		{Key: "DB_PASSWORD", Value: "p@ss\"word\n$HOME $HOME", Line: 4},
+		{Key: "URL", Value: "https://example.com/#anchor", Line: 5},
+		{Key: "EMPTY", Value: "", Line: 6},
+		{Key: "SINGLE", Value: `no \n escapes # here`, Line: 7},
+		{Key: "CERT", Value: "-----BEGIN-----\nline2\n-----END-----", Line: 9},
+		{Key: "LITERAL", Value: "a\nb", Line: 12},
+		{Key: "export_dir", Value: "/tmp", Line: 15},
+	}
+
+	got, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseCRLF(t *testing.T) {
+	got, err := Parse(strings.NewReader("A=1\r\nB=\"x\r\ny\"\r\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Value != "1" || got[1].Value != "x\ny" {
+		t.Errorf("Parse() = %+v", got)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		errMsg string
+	}{
+		{name: "missing equals", input: "A=1\nJUST_A_WORD\n", errMsg: "line 2: missing '='"},
+		{name: "invalid name", input: "1A=x\n", errMsg: "line 1: invalid variable name"},
+		{name: "name with dash", input: "MY-KEY=x\n", errMsg: "invalid variable name"},
+		{name: "unterminated quote", input: "A=1\nB=\"abc\nC=2\n", errMsg: "line 2: unterminated quoted value"},
+		{name: "text after quote", input: "A='x' y\n", errMsg: "unexpected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			if !errors.Is(err, ErrSyntax) || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Parse() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+// Package fileutil provides helpers to write files safely.
+package fileutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteAtomic writes data to a file so that readers either see the old or
+// the new content, never a partially written file. The data is written to a
+// temporary file in the same directory, synced and renamed over the target.
+//
+// Parameters:
+//   - name: The path of the file to write
+//   - data: The file content
+//   - perm: The permissions of the file
+//
+// Returns:
+//   - An error if the file can't be written
+func WriteAtomic(name string, data []byte, perm os.FileMode) error {
+	return WriteAtomicOwned(name, data, perm, -1, -1)
+}
+
+// WriteAtomicOwned works like WriteAtomic, but also changes the owner and
+// group of the file before it replaces the target. A uid or gid of -1 keeps
+// the owner or group of the current process.
+func WriteAtomicOwned(name string, data []byte, perm os.FileMode, uid, gid int) error {
+	dir, base := filepath.Split(name)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+base+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	// Removing fails once the file was renamed, which is fine
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to set permissions of %s: %w", name, err)
+	}
+	if uid != -1 || gid != -1 {
+		if err := tmp.Chown(uid, gid); err != nil {
+			_ = tmp.Close()
+			return fmt.Errorf("failed to set owner of %s: %w", name, err)
+		}
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to sync %s: %w", name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", name, err)
+	}
+	return nil
+}
+
+// Lock acquires an exclusive lock on the lock file name, creating it if
+// needed, and blocks until the lock is available. The lock is held until
+// the returned function is called. The lock file is kept, removing it would
+// allow two processes to lock different files of the same name.
+//
+// Parameters:
+//   - name: The path of the lock file
+//
+// Returns:
+//   - A function that releases the lock
+//   - An error if the lock can't be acquired
+func Lock(name string) (func() error, error) {
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	if err := lockFile(f); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", name, err)
+	}
+	// Closing the file releases the lock
+	return f.Close, nil
+}
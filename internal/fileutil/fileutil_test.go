@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteAtomic(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.env")
+
+	if err := os.WriteFile(name, []byte("old"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := WriteAtomic(name, []byte("new"), 0600); err != nil {
+		t.Fatalf("WriteAtomic() error = %v", err)
+	}
+
+	content, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(content) != "new" {
+		t.Errorf("content = %q, want %q", content, "new")
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatalf("Failed to stat file: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read directory: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want no temporary files left", len(entries))
+	}
+}
+
+func TestWriteAtomicMissingDirectory(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "missing", "app.env")
+	if err := WriteAtomic(name, []byte("x"), 0600); err == nil {
+		t.Error("WriteAtomic() error = nil, want error for missing directory")
+	}
+}
+
+func TestWriteAtomicOwned(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.env")
+	if err := WriteAtomicOwned(name, []byte("x"), 0640, os.Getuid(), os.Getgid()); err != nil {
+		t.Fatalf("WriteAtomicOwned() error = %v", err)
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatalf("Failed to stat file: %v", err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("mode = %v, want 0640", info.Mode().Perm())
+	}
+}
+
+func TestLock(t *testing.T) {
+	name := filepath.Join(t.TempDir(), ".app.env.lock")
+
+	unlock, err := Lock(name)
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+
+	locked := make(chan struct{})
+	go func() {
+		unlock, err := Lock(name)
+		if err != nil {
+			t.Errorf("second Lock() error = %v", err)
+			close(locked)
+			return
+		}
+		close(locked)
+		_ = unlock()
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("second Lock() returned while the lock was held")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := unlock(); err != nil {
+		t.Fatalf("unlock() error = %v", err)
+	}
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("second Lock() didn't return after the lock was released")
+	}
+}
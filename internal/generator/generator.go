@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+// Package generator creates random secret values.
+//
+// All randomness comes from crypto/rand. Values made of characters are
+// drawn uniformly from the selected character classes, with at least one
+// character of every class. Passphrases are built from the EFF large
+// wordlist.
+package generator
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/sethvargo/go-diceware/diceware"
+)
+
+// Supported encodings of a generated value
+const (
+	// EncodingChars draws characters from the selected character classes
+	EncodingChars = "chars"
+	// EncodingAlphanumeric draws lower and upper case letters and digits
+	EncodingAlphanumeric = "alphanumeric"
+	// EncodingHex encodes random bytes as hex
+	EncodingHex = "hex"
+	// EncodingBase64 encodes random bytes as standard base64
+	EncodingBase64 = "base64"
+	// EncodingPassphrase joins random words with a dash
+	EncodingPassphrase = "passphrase"
+)
+
+// Supported character classes
+const (
+	ClassLower   = "lower"
+	ClassUpper   = "upper"
+	ClassDigits  = "digits"
+	ClassSymbols = "symbols"
+)
+
+// Default and maximum lengths
+const (
+	// DefaultLength is the default number of characters or random bytes
+	DefaultLength = 32
+	// DefaultWords is the default number of words of a passphrase
+	DefaultWords = 6
+	// MaxLength is the maximum number of characters or random bytes
+	MaxLength = 1024
+	// MaxWords is the maximum number of words of a passphrase
+	MaxWords = 64
+)
+
+// classChars holds the characters of each character class. The symbols are
+// limited to characters that don't need quoting in URLs and most shells.
+var classChars = map[string]string{
+	ClassLower:   "abcdefghijklmnopqrstuvwxyz",
+	ClassUpper:   "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	ClassDigits:  "0123456789",
+	ClassSymbols: "!#%*+-.:=?@^_~",
+}
+
+// DefaultClasses are the character classes used by EncodingChars if none
+// are given
+var DefaultClasses = []string{ClassLower, ClassUpper, ClassDigits, ClassSymbols}
+
+// Options configure the generated value
+type Options struct {
+	// Encoding is one of the Encoding constants, default is EncodingChars
+	Encoding string
+	// Length is the number of characters, random bytes for hex and base64
+	// or words for a passphrase. Zero selects the default.
+	Length int
+	// Classes are the character classes for EncodingChars, default is
+	// DefaultClasses
+	Classes []string
+	// Exclude lists characters that must not appear in the value, only
+	// supported by EncodingChars and EncodingAlphanumeric
+	Exclude string
+}
+
+// Validate checks if the options are supported
+//
+// Parameters:
+//   - opts: The options to check
+//
+// Returns:
+//   - An error describing the first invalid option
+func Validate(opts Options) error {
+	opts = withDefaults(opts)
+
+	switch opts.Encoding {
+	case EncodingChars, EncodingAlphanumeric:
+	case EncodingHex, EncodingBase64, EncodingPassphrase:
+		if opts.Exclude != "" {
+			return fmt.Errorf("excluded characters are not supported by encoding '%s'", opts.Encoding)
+		}
+	default:
+		return fmt.Errorf("invalid encoding: %s (must be '%s', '%s', '%s', '%s' or '%s')", opts.Encoding,
+			EncodingChars, EncodingAlphanumeric, EncodingHex, EncodingBase64, EncodingPassphrase)
+	}
+
+	if opts.Encoding != EncodingChars && len(opts.Classes) > 0 {
+		return fmt.Errorf("character classes are only supported by encoding '%s'", EncodingChars)
+	}
+
+	maxLength := MaxLength
+	if opts.Encoding == EncodingPassphrase {
+		maxLength = MaxWords
+	}
+	if opts.Length < 1 || opts.Length > maxLength {
+		return fmt.Errorf("invalid length: %d (must be between 1 and %d)", opts.Length, maxLength)
+	}
+
+	if opts.Encoding == EncodingChars || opts.Encoding == EncodingAlphanumeric {
+		sets, err := charSets(opts)
+		if err != nil {
+			return err
+		}
+		if opts.Length < len(sets) {
+			return fmt.Errorf("invalid length: %d (must be at least %d to include every character class)", opts.Length, len(sets))
+		}
+	}
+	return nil
+}
+
+// Generate creates a random value
+//
+// Parameters:
+//   - opts: The encoding, length and characters of the value
+//
+// Returns:
+//   - The generated value
+//   - An error if the options are invalid or reading random data fails
+func Generate(opts Options) (string, error) {
+	if err := Validate(opts); err != nil {
+		return "", err
+	}
+	opts = withDefaults(opts)
+
+	switch opts.Encoding {
+	case EncodingHex, EncodingBase64:
+		b := make([]byte, opts.Length)
+		if _, err := rand.Read(b); err != nil {
+			return "", fmt.Errorf("failed to read random data: %w", err)
+		}
+		if opts.Encoding == EncodingHex {
+			return hex.EncodeToString(b), nil
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	case EncodingPassphrase:
+		words, err := diceware.Generate(opts.Length)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate passphrase: %w", err)
+		}
+		return strings.Join(words, "-"), nil
+	}
+
+	sets, err := charSets(opts)
+	if err != nil {
+		return "", err
+	}
+	return randomChars(sets, opts.Length)
+}
+
+// withDefaults fills the unset options with their defaults
+func withDefaults(opts Options) Options {
+	if opts.Encoding == "" {
+		opts.Encoding = EncodingChars
+	}
+	if opts.Length == 0 {
+		opts.Length = DefaultLength
+		if opts.Encoding == EncodingPassphrase {
+			opts.Length = DefaultWords
+		}
+	}
+	return opts
+}
+
+// charSets returns the characters of every selected class without the
+// excluded characters
+func charSets(opts Options) ([]string, error) {
+	classes := opts.Classes
+	switch {
+	case opts.Encoding == EncodingAlphanumeric:
+		classes = []string{ClassLower, ClassUpper, ClassDigits}
+	case len(classes) == 0:
+		classes = DefaultClasses
+	}
+
+	var sets []string
+	for _, class := range classes {
+		chars, ok := classChars[class]
+		if !ok {
+			return nil, fmt.Errorf("invalid character class: %s (must be '%s', '%s', '%s' or '%s')",
+				class, ClassLower, ClassUpper, ClassDigits, ClassSymbols)
+		}
+		chars = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(opts.Exclude, r) {
+				return -1
+			}
+			return r
+		}, chars)
+		if chars == "" {
+			return nil, fmt.Errorf("all characters of class '%s' are excluded", class)
+		}
+		if !slices.Contains(sets, chars) {
+			sets = append(sets, chars)
+		}
+	}
+	return sets, nil
+}
+
+// randomChars returns length random characters with at least one
+// character of every set
+func randomChars(sets []string, length int) (string, error) {
+	all := strings.Join(sets, "")
+	value := make([]byte, 0, length)
+	for _, set := range sets {
+		c, err := randomChar(set)
+		if err != nil {
+			return "", err
+		}
+		value = append(value, c)
+	}
+	for len(value) < length {
+		c, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+		value = append(value, c)
+	}
+
+	// Shuffle so the required characters aren't always at the start
+	for i := len(value) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		value[i], value[j] = value[j], value[i]
+	}
+	return string(value), nil
+}
+
+// randomChar returns a random character of chars
+func randomChar(chars string) (byte, error) {
+	i, err := randomInt(len(chars))
+	if err != nil {
+		return 0, err
+	}
+	return chars[i], nil
+}
+
+// randomInt returns a uniform random number in [0, n)
+func randomInt(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read random data: %w", err)
+	}
+	return int(i.Int64()), nil
+}
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package generator
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "defaults",
+			opts: Options{},
+		},
+		{
+			name: "passphrase",
+			opts: Options{Encoding: EncodingPassphrase, Length: 8},
+		},
+		{
+			name:    "invalid encoding",
+			opts:    Options{Encoding: "base32"},
+			wantErr: true,
+			errMsg:  "invalid encoding",
+		},
+		{
+			name:    "invalid class",
+			opts:    Options{Classes: []string{"emoji"}},
+			wantErr: true,
+			errMsg:  "invalid character class",
+		},
+		{
+			name:    "classes with hex",
+			opts:    Options{Encoding: EncodingHex, Classes: []string{ClassDigits}},
+			wantErr: true,
+			errMsg:  "only supported by encoding",
+		},
+		{
+			name:    "exclude with base64",
+			opts:    Options{Encoding: EncodingBase64, Exclude: "+/"},
+			wantErr: true,
+			errMsg:  "not supported by encoding",
+		},
+		{
+			name:    "negative length",
+			opts:    Options{Length: -1},
+			wantErr: true,
+			errMsg:  "invalid length",
+		},
+		{
+			name:    "too many words",
+			opts:    Options{Encoding: EncodingPassphrase, Length: MaxWords + 1},
+			wantErr: true,
+			errMsg:  "invalid length",
+		},
+		{
+			name:    "shorter than classes",
+			opts:    Options{Length: 3},
+			wantErr: true,
+			errMsg:  "every character class",
+		},
+		{
+			name:    "class fully excluded",
+			opts:    Options{Classes: []string{ClassDigits}, Exclude: "0123456789"},
+			wantErr: true,
+			errMsg:  "all characters of class 'digits' are excluded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  Options
+		check func(t *testing.T, value string)
+	}{
+		{
+			name: "chars include every class",
+			opts: Options{Length: 4},
+			check: func(t *testing.T, value string) {
+				if len(value) != 4 {
+					t.Errorf("length = %d, want 4", len(value))
+				}
+				for _, class := range DefaultClasses {
+					if !strings.ContainsAny(value, classChars[class]) {
+						t.Errorf("value has no character of class '%s'", class)
+					}
+				}
+			},
+		},
+		{
+			name: "alphanumeric with excluded characters",
+			opts: Options{Encoding: EncodingAlphanumeric, Length: 200, Exclude: "0OIl1"},
+			check: func(t *testing.T, value string) {
+				if len(value) != 200 {
+					t.Errorf("length = %d, want 200", len(value))
+				}
+				if strings.ContainsAny(value, "0OIl1") {
+					t.Errorf("value contains excluded characters")
+				}
+				if strings.ContainsAny(value, classChars[ClassSymbols]) {
+					t.Errorf("value contains symbols")
+				}
+			},
+		},
+		{
+			name: "digits only",
+			opts: Options{Classes: []string{ClassDigits}, Length: 12},
+			check: func(t *testing.T, value string) {
+				if strings.Trim(value, classChars[ClassDigits]) != "" || len(value) != 12 {
+					t.Errorf("value %q isn't 12 digits", value)
+				}
+			},
+		},
+		{
+			name: "hex",
+			opts: Options{Encoding: EncodingHex, Length: 16},
+			check: func(t *testing.T, value string) {
+				b, err := hex.DecodeString(value)
+				if err != nil || len(b) != 16 {
+					t.Errorf("value %q isn't 16 hex encoded bytes", value)
+				}
+			},
+		},
+		{
+			name: "base64",
+			opts: Options{Encoding: EncodingBase64},
+			check: func(t *testing.T, value string) {
+				b, err := base64.StdEncoding.DecodeString(value)
+				if err != nil || len(b) != DefaultLength {
+					t.Errorf("value %q isn't %d base64 encoded bytes", value, DefaultLength)
+				}
+			},
+		},
+		{
+			name: "passphrase",
+			opts: Options{Encoding: EncodingPassphrase},
+			check: func(t *testing.T, value string) {
+				if words := strings.Split(value, "-"); len(words) < DefaultWords {
+					t.Errorf("passphrase %q has %d words, want at least %d", value, len(words), DefaultWords)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := Generate(tt.opts)
+			if err != nil {
+				t.Fatalf("Generate() unexpected error = %v", err)
+			}
+			tt.check(t, value)
+
+			again, err := Generate(tt.opts)
+			if err != nil {
+				t.Fatalf("Generate() unexpected error = %v", err)
+			}
+			if value == again {
+				t.Errorf("Generate() returned the same value twice")
+			}
+		})
+	}
+}
+
+func TestGenerateInvalidOptions(t *testing.T) {
+	if _, err := Generate(Options{Encoding: "rot13"}); err == nil {
+		t.Error("Generate() expected error for invalid encoding")
+	}
+}
@@ -0,0 +1,265 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+// Package manifest loads declarative parameter definitions for the apply
+// command.
+//
+// A manifest is a YAML file listing the desired state of parameters: path,
+// type, description, KMS key, tags, tier, replica regions and where the
+// value comes from. Exactly one value source is allowed per parameter: a
+// literal value, an environment variable, a file or a generator that only
+// runs if the parameter doesn't exist yet.
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+	"git.sr.ht/~wombelix/params2env/internal/generator"
+	"git.sr.ht/~wombelix/params2env/internal/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// Common errors returned by the package
+var (
+	ErrInvalidManifest = errors.New("invalid manifest")
+)
+
+// Manifest is the desired state of a set of parameters
+type Manifest struct {
+	// Region is the primary AWS region of all parameters
+	Region string `yaml:"region,omitempty"`
+	// Role is the AWS IAM role to assume
+	Role string `yaml:"role,omitempty"`
+	// Prefix is the parameter subtree managed by the manifest. Parameters
+	// below the prefix that aren't listed are reported as orphaned.
+	Prefix string `yaml:"prefix,omitempty"`
+	// Parameters are the desired parameters
+	Parameters []Parameter `yaml:"parameters"`
+
+	// dir is the directory of the manifest file, value files are
+	// resolved relative to it
+	dir string
+}
+
+// Parameter is the desired state of a single parameter
+type Parameter struct {
+	// Path is the full path of the parameter (required)
+	Path string `yaml:"path"`
+	// Type is the parameter type, default is String or SecureString for
+	// generated values
+	Type string `yaml:"type,omitempty"`
+	// Description is the parameter description
+	Description string `yaml:"description,omitempty"`
+	// KMS is the KMS key ID for SecureString parameters
+	KMS string `yaml:"kms,omitempty"`
+	// Tags are added to the parameter, other existing tags are kept
+	Tags map[string]string `yaml:"tags,omitempty"`
+	// Tier is the parameter tier (Standard, Advanced or Intelligent-Tiering)
+	Tier string `yaml:"tier,omitempty"`
+	// Replicas are the regions the parameter is replicated to
+	Replicas []string `yaml:"replicas,omitempty"`
+	// Value is a literal value
+	Value string `yaml:"value,omitempty"`
+	// ValueEnv is the environment variable holding the value
+	ValueEnv string `yaml:"value_env,omitempty"`
+	// ValueFile is the file holding the value, relative to the manifest
+	ValueFile string `yaml:"value_file,omitempty"`
+	// Generate creates a random value if the parameter doesn't exist
+	Generate *Generate `yaml:"generate,omitempty"`
+}
+
+// Generate holds the options of a generated value
+type Generate struct {
+	// Encoding is chars, alphanumeric, hex, base64 or passphrase
+	Encoding string `yaml:"encoding,omitempty"`
+	// Length is the number of characters, random bytes or words
+	Length int `yaml:"length,omitempty"`
+	// Classes are the character classes for the chars encoding
+	Classes []string `yaml:"classes,omitempty"`
+	// ExcludeChars lists characters the value must not contain
+	ExcludeChars string `yaml:"exclude_chars,omitempty"`
+}
+
+// Options returns the generator options
+func (g Generate) Options() generator.Options {
+	return generator.Options{
+		Encoding: g.Encoding,
+		Length:   g.Length,
+		Classes:  g.Classes,
+		Exclude:  g.ExcludeChars,
+	}
+}
+
+// Load reads and validates a manifest file. Unknown fields are rejected to
+// catch typos early. Default types are filled in.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", path, err)
+	}
+
+	var m Manifest
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML in %s: %w", path, err)
+	}
+	m.dir = filepath.Dir(path)
+
+	for i := range m.Parameters {
+		if m.Parameters[i].Type == "" {
+			m.Parameters[i].Type = aws.ParameterTypeString
+			if m.Parameters[i].Generate != nil {
+				m.Parameters[i].Type = aws.ParameterTypeSecureString
+			}
+		}
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Validate checks if the manifest is valid
+func (m *Manifest) Validate() error {
+	if err := validation.ValidateRegion(m.Region); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+	if err := validation.ValidateRoleARN(m.Role); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+	if m.Prefix != "" {
+		if err := validation.ValidateParameterPath(m.Prefix); err != nil {
+			return fmt.Errorf("%w: invalid prefix: %w", ErrInvalidManifest, err)
+		}
+	}
+	if len(m.Parameters) == 0 {
+		return fmt.Errorf("%w: no parameters defined", ErrInvalidManifest)
+	}
+
+	seen := make(map[string]bool, len(m.Parameters))
+	for i, p := range m.Parameters {
+		if p.Path == "" {
+			return fmt.Errorf("%w: parameter at index %d missing path", ErrInvalidManifest, i)
+		}
+		if seen[p.Path] {
+			return fmt.Errorf("%w: parameter %s is defined more than once", ErrInvalidManifest, p.Path)
+		}
+		seen[p.Path] = true
+		if err := m.validateParameter(p); err != nil {
+			return fmt.Errorf("%w: parameter %s: %w", ErrInvalidManifest, p.Path, err)
+		}
+	}
+	return nil
+}
+
+// validateParameter checks a single parameter definition
+func (m *Manifest) validateParameter(p Parameter) error {
+	if err := validation.ValidateParameterPath(p.Path); err != nil {
+		return err
+	}
+	if m.Prefix != "" && !strings.HasPrefix(p.Path, m.Prefix+"/") {
+		return fmt.Errorf("path is not below prefix %s", m.Prefix)
+	}
+
+	if !aws.IsValidParameterType(p.Type) {
+		return fmt.Errorf("invalid type %s (must be '%s', '%s' or '%s')",
+			p.Type, aws.ParameterTypeString, aws.ParameterTypeStringList, aws.ParameterTypeSecureString)
+	}
+	if p.KMS != "" && p.Type != aws.ParameterTypeSecureString {
+		return fmt.Errorf("kms can only be set for %s parameters", aws.ParameterTypeSecureString)
+	}
+	if err := validation.ValidateKMSKey(p.KMS); err != nil {
+		return err
+	}
+	if p.Tier != "" && !aws.IsValidParameterTier(p.Tier) {
+		return fmt.Errorf("invalid tier %s (must be '%s', '%s' or '%s')",
+			p.Tier, aws.ParameterTierStandard, aws.ParameterTierAdvanced, aws.ParameterTierIntelligentTiering)
+	}
+	for _, replica := range p.Replicas {
+		if err := validation.ValidateRegion(replica); err != nil {
+			return fmt.Errorf("invalid replica region: %w", err)
+		}
+	}
+
+	sources := 0
+	for _, set := range []bool{p.Value != "", p.ValueEnv != "", p.ValueFile != "", p.Generate != nil} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("exactly one of value, value_env, value_file or generate must be set")
+	}
+
+	// Values from environment variables and files are checked when read
+	if p.Value != "" && p.Type == aws.ParameterTypeStringList {
+		if err := validation.ValidateStringListItems(strings.Split(p.Value, ",")); err != nil {
+			return err
+		}
+	}
+
+	if p.Generate != nil {
+		if p.Type == aws.ParameterTypeStringList {
+			return fmt.Errorf("generate can't be used with %s parameters", aws.ParameterTypeStringList)
+		}
+		if err := generator.Validate(p.Generate.Options()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadValue returns the value of a parameter from its literal value,
+// environment variable or file. Files are read as they are, relative to
+// the manifest. Generated values are created by the caller, because they
+// depend on the current state. The items of StringList values are
+// validated.
+func (m *Manifest) ReadValue(p Parameter) (string, error) {
+	value, err := m.readValue(p)
+	if err != nil {
+		return "", err
+	}
+	if p.Type == aws.ParameterTypeStringList {
+		if err := validation.ValidateStringListItems(strings.Split(value, ",")); err != nil {
+			return "", fmt.Errorf("invalid value for parameter %s: %w", p.Path, err)
+		}
+	}
+	return value, nil
+}
+
+// readValue returns the value of a parameter from its source
+func (m *Manifest) readValue(p Parameter) (string, error) {
+	switch {
+	case p.Value != "":
+		return p.Value, nil
+	case p.ValueEnv != "":
+		value, ok := os.LookupEnv(p.ValueEnv)
+		if !ok || value == "" {
+			return "", fmt.Errorf("environment variable %s for parameter %s is not set", p.ValueEnv, p.Path)
+		}
+		return value, nil
+	case p.ValueFile != "":
+		file := p.ValueFile
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(m.dir, file)
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read value file for parameter %s: %w", p.Path, err)
+		}
+		if len(data) == 0 {
+			return "", fmt.Errorf("value file for parameter %s is empty", p.Path)
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("parameter %s has no static value", p.Path)
+}
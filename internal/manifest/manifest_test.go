@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: MIT
+
+package manifest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~wombelix/params2env/internal/aws"
+)
+
+func writeManifest(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "params.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeManifest(t, `
+region: eu-central-1
+prefix: /myapp
+parameters:
+  - path: /myapp/url
+    value: https://example.com
+    tags:
+      team: platform
+  - path: /myapp/db/password
+    kms: alias/myapp
+    tier: Advanced
+    replicas: [eu-west-1]
+    generate:
+      encoding: alphanumeric
+      length: 40
+`)
+
+	m, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if m.Region != "eu-central-1" || m.Prefix != "/myapp" || len(m.Parameters) != 2 {
+		t.Fatalf("Load() = %+v", m)
+	}
+	if got := m.Parameters[0].Type; got != aws.ParameterTypeString {
+		t.Errorf("default type = %s, want %s", got, aws.ParameterTypeString)
+	}
+	if got := m.Parameters[1].Type; got != aws.ParameterTypeSecureString {
+		t.Errorf("default type of generated value = %s, want %s", got, aws.ParameterTypeSecureString)
+	}
+	if got := m.Parameters[1].Generate.Options().Length; got != 40 {
+		t.Errorf("generate length = %d, want 40", got)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{
+			name:    "unknown field",
+			content: "parameters:\n  - path: /a\n    value: x\n    valeu: y\n",
+			errMsg:  "field valeu not found",
+		},
+		{
+			name:    "no parameters",
+			content: "region: eu-central-1\n",
+			errMsg:  "no parameters defined",
+		},
+		{
+			name:    "missing path",
+			content: "parameters:\n  - value: x\n",
+			errMsg:  "missing path",
+		},
+		{
+			name:    "duplicate path",
+			content: "parameters:\n  - path: /a\n    value: x\n  - path: /a\n    value: y\n",
+			errMsg:  "defined more than once",
+		},
+		{
+			name:    "outside prefix",
+			content: "prefix: /app\nparameters:\n  - path: /other/a\n    value: x\n",
+			errMsg:  "not below prefix",
+		},
+		{
+			name:    "no value source",
+			content: "parameters:\n  - path: /a\n",
+			errMsg:  "exactly one of",
+		},
+		{
+			name:    "two value sources",
+			content: "parameters:\n  - path: /a\n    value: x\n    value_env: X\n",
+			errMsg:  "exactly one of",
+		},
+		{
+			name:    "invalid type",
+			content: "parameters:\n  - path: /a\n    type: Secret\n    value: x\n",
+			errMsg:  "invalid type",
+		},
+		{
+			name:    "invalid tier",
+			content: "parameters:\n  - path: /a\n    tier: Premium\n    value: x\n",
+			errMsg:  "invalid tier",
+		},
+		{
+			name:    "kms for string",
+			content: "parameters:\n  - path: /a\n    kms: alias/x\n    value: x\n",
+			errMsg:  "kms can only be set",
+		},
+		{
+			name:    "invalid generator",
+			content: "parameters:\n  - path: /a\n    generate:\n      encoding: rot13\n",
+			errMsg:  "invalid encoding",
+		},
+		{
+			name:    "empty string list item",
+			content: "parameters:\n  - path: /a\n    type: StringList\n    value: a,,b\n",
+			errMsg:  "must not be empty",
+		},
+		{
+			name:    "invalid replica",
+			content: "parameters:\n  - path: /a\n    value: x\n    replicas: [nowhere]\n",
+			errMsg:  "invalid replica region",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeManifest(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Load() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+
+	if _, err := Load(writeManifest(t, "parameters:\n  - value: x\n")); !errors.Is(err, ErrInvalidManifest) {
+		t.Errorf("Load() error = %v, want ErrInvalidManifest", err)
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Load() expected error for missing file")
+	}
+}
+
+func TestReadValue(t *testing.T) {
+	path := writeManifest(t, "parameters:\n  - path: /a\n    value: x\n")
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), "cert.pem"), []byte("cert\n"), 0600); err != nil {
+		t.Fatalf("Failed to write value file: %v", err)
+	}
+	m, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	t.Setenv("MANIFEST_TEST_VALUE", "from-env")
+	t.Setenv("MANIFEST_TEST_LIST", "a,b")
+	t.Setenv("MANIFEST_TEST_INVALID_LIST", "a,")
+
+	tests := []struct {
+		name    string
+		param   Parameter
+		want    string
+		wantErr bool
+	}{
+		{name: "literal", param: Parameter{Path: "/a", Value: "literal"}, want: "literal"},
+		{name: "env", param: Parameter{Path: "/a", ValueEnv: "MANIFEST_TEST_VALUE"}, want: "from-env"},
+		{name: "env missing", param: Parameter{Path: "/a", ValueEnv: "MANIFEST_TEST_MISSING"}, wantErr: true},
+		{name: "file relative to manifest", param: Parameter{Path: "/a", ValueFile: "cert.pem"}, want: "cert\n"},
+		{name: "file missing", param: Parameter{Path: "/a", ValueFile: "missing.pem"}, wantErr: true},
+		{name: "generated", param: Parameter{Path: "/a", Generate: &Generate{}}, wantErr: true},
+		{name: "string list", param: Parameter{Path: "/a", Type: aws.ParameterTypeStringList, ValueEnv: "MANIFEST_TEST_LIST"}, want: "a,b"},
+		{name: "string list empty item", param: Parameter{Path: "/a", Type: aws.ParameterTypeStringList, ValueEnv: "MANIFEST_TEST_INVALID_LIST"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.ReadValue(tt.param)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ReadValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
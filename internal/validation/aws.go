@@ -9,6 +9,7 @@
 // - AWS Region names
 // - AWS KMS Key IDs and ARNs
 // - AWS IAM Role ARNs
+// - StringList parameter items
 package validation
 
 import (
@@ -112,6 +113,25 @@ func ValidateRegions(primary, replica string) error {
 	return nil
 }
 
+// ValidateReplicaRegions checks a list of replica regions. Every region must
+// be valid, differ from the primary region and appear only once.
+func ValidateReplicaRegions(primary string, replicas []string) error {
+	seen := make(map[string]bool, len(replicas))
+	for _, replica := range replicas {
+		if err := ValidateRegion(replica); err != nil {
+			return fmt.Errorf("invalid replica region: %w", err)
+		}
+		if err := ValidateRegions(primary, replica); err != nil {
+			return err
+		}
+		if seen[replica] {
+			return fmt.Errorf("replica region '%s' specified more than once", replica)
+		}
+		seen[replica] = true
+	}
+	return nil
+}
+
 // ValidateSecureStringRequirements ensures KMS key is provided for SecureString parameters.
 // This prevents accidental use of AWS managed keys when custom encryption is expected.
 func ValidateSecureStringRequirements(paramType, kmsKey string) error {
@@ -120,3 +140,21 @@ func ValidateSecureStringRequirements(paramType, kmsKey string) error {
 	}
 	return nil
 }
+
+// ValidateStringListItems checks that the given items can be stored in a
+// StringList parameter. SSM separates StringList items with commas, so an
+// item must not contain a comma and must not be empty.
+func ValidateStringListItems(items []string) error {
+	if len(items) == 0 {
+		return fmt.Errorf("StringList parameter requires at least one item")
+	}
+	for i, item := range items {
+		if item == "" {
+			return fmt.Errorf("StringList item at index %d must not be empty", i)
+		}
+		if strings.Contains(item, ",") {
+			return fmt.Errorf("StringList item %q must not contain ','", item)
+		}
+	}
+	return nil
+}
@@ -297,6 +297,30 @@ func TestValidateRegions(t *testing.T) {
 	}
 }
 
+func TestValidateReplicaRegions(t *testing.T) {
+	tests := []struct {
+		name     string
+		primary  string
+		replicas []string
+		wantErr  bool
+	}{
+		{"no_replicas", "us-east-1", nil, false},
+		{"multiple_replicas", "us-east-1", []string{"eu-west-1", "us-west-2"}, false},
+		{"invalid_replica", "us-east-1", []string{"eu-west-1", "invalid"}, true},
+		{"replica_equals_primary", "us-east-1", []string{"eu-west-1", "us-east-1"}, true},
+		{"duplicate_replica", "us-east-1", []string{"eu-west-1", "eu-west-1"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReplicaRegions(tt.primary, tt.replicas)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateReplicaRegions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateSecureStringRequirements(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -345,3 +369,46 @@ func TestValidateSecureStringRequirements(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateStringListItems(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []string
+		wantErr bool
+	}{
+		{
+			name:    "single_item",
+			items:   []string{"a"},
+			wantErr: false,
+		},
+		{
+			name:    "multiple_items",
+			items:   []string{"a", "b", "c"},
+			wantErr: false,
+		},
+		{
+			name:    "no_items",
+			items:   nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty_item",
+			items:   []string{"a", ""},
+			wantErr: true,
+		},
+		{
+			name:    "item_with_comma",
+			items:   []string{"a", "b,c"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStringListItems(tt.items)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateStringListItems() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}